@@ -0,0 +1,148 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// ErrTooManyFailures is returned by ValidateBatch when BatchOptions.MaxFailures
+// invalid items have been found and the remaining items were not validated.
+var ErrTooManyFailures = errors.New("validate: too many failures")
+
+// BatchOptions controls how ValidateBatch spreads work across goroutines.
+type BatchOptions struct {
+	// Workers is the maximum number of goroutines validating items at the
+	// same time. Values less than 1 mean runtime.GOMAXPROCS(0).
+	Workers int
+
+	// MaxFailures stops the batch once that many items have failed
+	// validation. Zero means no limit.
+	MaxFailures int
+}
+
+// ValidateBatch validates every element of items, which must be a slice or an
+// array (or a pointer to one), using at most opts.Workers goroutines.
+//
+// The result maps the index of each invalid element to the errors Validate
+// returned for it; valid elements are absent. Items are handed out in input
+// order, so when the batch stops early the result holds exactly the failures
+// a sequential loop would have found up to that point.
+//
+// Items are validated with ValidateContext, through a pointer when the
+// elements are addressable, so modifiers and defaults are written back to
+// the elements of slices and of arrays passed by pointer. Arrays passed by
+// value are validated on a copy and left unchanged.
+//
+// If ctx is cancelled the batch stops and ctx.Err() is returned alongside the
+// failures found so far; items interrupted by the cancellation are not
+// reported. If opts.MaxFailures is reached, the batch stops, only the first
+// opts.MaxFailures failures are kept and ErrTooManyFailures is returned.
+func (v V) ValidateBatch(ctx context.Context, items interface{}, opts BatchOptions) (map[int]map[string]interface{}, error) {
+	list := reflect.ValueOf(items)
+	if list.Kind() == reflect.Ptr {
+		list = list.Elem()
+	}
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return nil, fmt.Errorf("validate: ValidateBatch expects a slice, got %T", items)
+	}
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if n := list.Len(); workers > n {
+		workers = n
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures = make(map[int]map[string]interface{})
+		limitHit bool
+	)
+
+	indices := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				item := list.Index(i)
+				if item.CanAddr() {
+					item = item.Addr()
+				}
+				errs, err := v.ValidateContext(ctx, item.Interface())
+				if errs == nil || err != nil {
+					continue
+				}
+				mu.Lock()
+				failures[i] = errs
+				if opts.MaxFailures > 0 && len(failures) >= opts.MaxFailures {
+					limitHit = true
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for i := 0; i < list.Len(); i++ {
+		mu.Lock()
+		stop := limitHit
+		mu.Unlock()
+		if stop {
+			err = ErrTooManyFailures
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	/* Items handed out last may have failed or been interrupted after the
+	 * feed ended */
+	if limitHit {
+		err = ErrTooManyFailures
+	} else if ctxErr := ctx.Err(); err == nil && ctxErr != nil {
+		err = ctxErr
+	}
+
+	if err == ErrTooManyFailures {
+		trimFailures(failures, opts.MaxFailures)
+	}
+	if len(failures) == 0 {
+		return nil, err
+	}
+	return failures, err
+}
+
+// trimFailures keeps only the max lowest indices in failures. Items that
+// were already in flight when the limit was reached may have added more.
+func trimFailures(failures map[int]map[string]interface{}, max int) {
+	if len(failures) <= max {
+		return
+	}
+	idx := make([]int, 0, len(failures))
+	for i := range failures {
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+	for _, i := range idx[max:] {
+		delete(failures, i)
+	}
+}
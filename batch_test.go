@@ -0,0 +1,200 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func batchValidator() V {
+	vd := make(V)
+	vd["odd"] = func(i interface{}) interface{} {
+		n := i.(int)
+		if n&1 == 0 {
+			return fmt.Errorf("%d is not odd", n)
+		}
+		return nil
+	}
+	return vd
+}
+
+type batchItem struct {
+	A int `validate:"odd"`
+}
+
+func TestV_ValidateBatch(t *testing.T) {
+	vd := batchValidator()
+
+	items := make([]batchItem, 100)
+	for i := range items {
+		items[i].A = i
+	}
+
+	errs, err := vd.ValidateBatch(context.Background(), items, BatchOptions{Workers: 4})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(errs) != 50 {
+		t.Fatal("wrong number of failed items: expected 50; got:", len(errs))
+	}
+	for i := 0; i < len(items); i += 2 {
+		if errs[i] == nil {
+			t.Fatalf("expected errors for item %d: %v", i, errs)
+		}
+		if errs[i]["A"].(error).Error() != fmt.Sprintf("%d is not odd", i) {
+			t.Fatalf("wrong error for item %d: %v", i, errs[i])
+		}
+	}
+}
+
+func TestV_ValidateBatch_allgood(t *testing.T) {
+	vd := batchValidator()
+
+	items := []*batchItem{{A: 1}, {A: 3}, {A: 5}}
+	errs, err := vd.ValidateBatch(context.Background(), items, BatchOptions{})
+	if err != nil || errs != nil {
+		t.Fatal("unexpected errors for valid items:", errs, err)
+	}
+
+	errs, err = vd.ValidateBatch(context.Background(), []batchItem{}, BatchOptions{})
+	if err != nil || errs != nil {
+		t.Fatal("unexpected errors for an empty batch:", errs, err)
+	}
+}
+
+func TestV_ValidateBatch_max_failures(t *testing.T) {
+	vd := batchValidator()
+
+	items := make([]batchItem, 1000)
+	for i := range items {
+		items[i].A = i
+	}
+
+	errs, err := vd.ValidateBatch(context.Background(), items, BatchOptions{
+		Workers:     8,
+		MaxFailures: 3,
+	})
+	if err != ErrTooManyFailures {
+		t.Fatal("wrong error: expected ErrTooManyFailures; got:", err)
+	}
+	if len(errs) != 3 {
+		t.Fatal("wrong number of failed items: expected 3; got:", errs)
+	}
+	for _, i := range []int{0, 2, 4} {
+		if errs[i] == nil {
+			t.Fatalf("expected the first failures to be reported, missing %d: %v", i, errs)
+		}
+	}
+}
+
+func TestV_ValidateBatch_max_failures_last(t *testing.T) {
+	vd := batchValidator()
+
+	/* The limit is reached after every item has been handed out */
+	for _, opts := range []BatchOptions{{Workers: 1, MaxFailures: 1}, {Workers: 3, MaxFailures: 1}} {
+		items := []batchItem{{A: 0}, {A: 2}, {A: 4}}
+		if opts.Workers == 1 {
+			items = items[:2]
+		}
+		errs, err := vd.ValidateBatch(context.Background(), items, opts)
+		if err != ErrTooManyFailures {
+			t.Fatalf("wrong error for %+v: expected ErrTooManyFailures; got: %v", opts, err)
+		}
+		if len(errs) != 1 || errs[0] == nil {
+			t.Fatalf("expected only the first failure for %+v; got: %v", opts, errs)
+		}
+	}
+}
+
+func TestV_ValidateBatch_modified(t *testing.T) {
+	type X struct {
+		A string `validate:"trim,short"`
+		N int    `validate:"default=3"`
+	}
+	vd := modifierValidator()
+
+	items := []X{{A: " a "}, {A: " b ", N: 1}}
+	if errs, err := vd.ValidateBatch(context.Background(), items, BatchOptions{Workers: 2}); errs != nil || err != nil {
+		t.Fatal("unexpected errors:", errs, err)
+	}
+	if items[0] != (X{"a", 3}) || items[1] != (X{"b", 1}) {
+		t.Fatal("modifiers and defaults should be written back to the slice; got:", items)
+	}
+
+	/* Arrays passed by value are validated on a copy */
+	arr := [1]X{{A: " a "}}
+	if errs, err := vd.ValidateBatch(context.Background(), arr, BatchOptions{}); errs != nil || err != nil {
+		t.Fatal("unexpected errors:", errs, err)
+	}
+	if arr[0] != (X{A: " a "}) {
+		t.Fatal("an array passed by value should be left unchanged; got:", arr)
+	}
+	if _, err := vd.ValidateBatch(context.Background(), &arr, BatchOptions{}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if arr[0] != (X{"a", 3}) {
+		t.Fatal("modifiers and defaults should be written back through a pointer; got:", arr)
+	}
+}
+
+func TestV_ValidateBatch_cancel(t *testing.T) {
+	var calls int32
+	vd := make(V)
+	vd["count"] = func(i interface{}) interface{} {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+
+	type X struct {
+		A int `validate:"count"`
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs, err := vd.ValidateBatch(ctx, make([]X, 100), BatchOptions{Workers: 2})
+	if err != context.Canceled {
+		t.Fatal("wrong error: expected context.Canceled; got:", err)
+	}
+	if errs != nil {
+		t.Fatal("unexpected errors:", errs)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatal("no items should be validated after cancellation; got:", n)
+	}
+}
+
+func TestV_ValidateBatch_cancel_in_flight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var started int32
+	vd := make(V)
+	vd["wait"] = WithContext(func(ctx context.Context, fc FieldContext) error {
+		/* Cancel once every item has been handed out */
+		if atomic.AddInt32(&started, 1) == 2 {
+			cancel()
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	type X struct {
+		A int `validate:"wait"`
+	}
+
+	errs, err := vd.ValidateBatch(ctx, make([]X, 2), BatchOptions{Workers: 2})
+	if err != context.Canceled {
+		t.Fatal("wrong error: expected context.Canceled; got:", err)
+	}
+	if errs != nil {
+		t.Fatal("interrupted items should not be reported:", errs)
+	}
+}
+
+func TestV_ValidateBatch_nonslice(t *testing.T) {
+	vd := batchValidator()
+
+	if _, err := vd.ValidateBatch(context.Background(), batchItem{}, BatchOptions{}); err == nil {
+		t.Fatal("expected an error for a non-slice batch")
+	}
+}
@@ -0,0 +1,40 @@
+package validate
+
+import "context"
+
+// FieldContext describes the field being checked by a ValidatorCtxFn.
+type FieldContext struct {
+	// Value is the value of the field, as the other validators see it.
+	Value interface{}
+	// Name is the key the field's errors are reported under.
+	Name string
+	// Path is the dot-separated list of names leading to the field from the
+	// top-level struct, e.g. "address.zip".
+	Path string
+	// Parent is the struct holding the field, or a pointer to it when the
+	// struct is addressable.
+	Parent interface{}
+	// Top is the value passed to Validate or ValidateContext.
+	Top interface{}
+}
+
+// ValidatorCtxFn is a validator that has access to the context passed to
+// ValidateContext and to the surroundings of the field.
+type ValidatorCtxFn func(ctx context.Context, fc FieldContext) error
+
+// ctxCall is a pending call of a ValidatorCtxFn, completed by the engine.
+type ctxCall struct {
+	fn  ValidatorCtxFn
+	val interface{}
+}
+
+// WithContext adapts fn so it can be registered in V alongside plain
+// validators. Validate runs it with context.Background().
+//
+// The adapted function only works through Validate and ValidateContext;
+// calling it directly does not run fn.
+func WithContext(fn ValidatorCtxFn) ValidatorFn {
+	return func(i interface{}) interface{} {
+		return ctxCall{fn, i}
+	}
+}
@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestV_ValidateContext(t *testing.T) {
+	type Z struct {
+		B string `json:"b" validate:"tenant"`
+	}
+	type X struct {
+		Tenant string
+		A      Z `json:"a" validate:"struct"`
+	}
+
+	var seen FieldContext
+	vd := make(V)
+	vd["tenant"] = WithContext(func(ctx context.Context, fc FieldContext) error {
+		seen = fc
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		if fc.Value.(string) != tenant {
+			return fmt.Errorf("%q does not belong to %q", fc.Value, tenant)
+		}
+		return nil
+	})
+
+	x := X{Tenant: "acme", A: Z{B: "acme"}}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	errs, err := vd.ValidateContext(ctx, &x)
+	if errs != nil || err != nil {
+		t.Fatal("unexpected errors:", errs, err)
+	}
+
+	if seen.Name != "b" || seen.Path != "a.b" || seen.Value != "acme" {
+		t.Fatalf("wrong field context: %+v", seen)
+	}
+	if seen.Parent != &x.A {
+		t.Fatalf("wrong parent: expected %p; got: %#v", &x.A, seen.Parent)
+	}
+	if seen.Top != &x {
+		t.Fatalf("wrong top-level value: %#v", seen.Top)
+	}
+
+	ctx = context.WithValue(context.Background(), tenantKey{}, "other")
+	errs, err = vd.ValidateContext(ctx, x)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	nested, ok := errs["a"].(map[string]interface{})
+	if !ok || nested["b"].(error).Error() != `"acme" does not belong to "other"` {
+		t.Fatal("wrong errors:", errs)
+	}
+
+	/* Plain Validate runs context validators with an empty context */
+	errs = vd.Validate(x)
+	if errs == nil {
+		t.Fatal("expected errors for a missing tenant")
+	}
+}
+
+func TestV_ValidateContext_cancel(t *testing.T) {
+	type X struct {
+		A int `validate:"fail"`
+		B int `validate:"cancel"`
+		C int `validate:"cancel"`
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	vd := make(V)
+	vd["fail"] = WithContext(func(ctx context.Context, fc FieldContext) error {
+		return errors.New("invalid")
+	})
+	vd["cancel"] = WithContext(func(ctx context.Context, fc FieldContext) error {
+		calls++
+		cancel()
+		return ctx.Err()
+	})
+
+	errs, err := vd.ValidateContext(ctx, X{})
+	if err != context.Canceled {
+		t.Fatal("wrong error: expected context.Canceled; got:", err)
+	}
+	if calls != 1 || len(errs) != 1 || errs["A"] == nil {
+		t.Fatal("validation should stop after the second field:", calls, errs)
+	}
+	if _, ok := errs["B"]; ok {
+		t.Fatal("the cancellation should not be reported as a failure:", errs)
+	}
+
+	/* Cancelled by the last field */
+	type Y struct {
+		A int `validate:"cancel"`
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	errs, err = vd.ValidateContext(ctx, Y{})
+	if err != context.Canceled || errs != nil {
+		t.Fatal("wrong result: expected no errors and context.Canceled; got:", errs, err)
+	}
+}
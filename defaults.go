@@ -0,0 +1,132 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// defaultLiteral returns the literal of a default entry, written either as
+// `default=x` or as `default(x)`.
+func defaultLiteral(vt string) (string, bool) {
+	switch {
+	case strings.HasPrefix(vt, "default="):
+		return vt[len("default="):], true
+	case strings.HasPrefix(vt, "default(") && strings.HasSuffix(vt, ")"):
+		return vt[len("default(") : len(vt)-1], true
+	}
+	return "", false
+}
+
+// splitDefault removes the default entry from a list of tag entries and
+// returns its literal.
+func splitDefault(vts []string) ([]string, string, bool) {
+	for i, vt := range vts {
+		if def, ok := defaultLiteral(vt); ok {
+			rest := append(vts[:i:i], vts[i+1:]...)
+			return rest, def, true
+		}
+	}
+	return vts, "", false
+}
+
+// defaultError is the failure of a field whose default cannot be parsed.
+func defaultError(def string, err error) Error {
+	return NewError("default.invalid",
+		fmt.Sprintf("invalid default %q: %v", def, err), Params{"default": def})
+}
+
+// parseDefault converts the literal s into a value of type t.
+//
+// Strings are taken as is; numbers and bools are parsed with strconv,
+// integers in base 10; durations with time.ParseDuration; times as RFC
+// 3339 or a plain date (2006-01-02); slice elements are separated with
+// '|'. Pointers get a newly allocated value.
+func parseDefault(t reflect.Type, s string) (reflect.Value, error) {
+	res := reflect.New(t).Elem()
+
+	switch {
+	case t == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return res, err
+		}
+		res.SetInt(int64(d))
+		return res, nil
+
+	case t == timeType:
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			tm, err = time.Parse("2006-01-02", s)
+		}
+		if err != nil {
+			return res, err
+		}
+		res.Set(reflect.ValueOf(tm))
+		return res, nil
+	}
+
+	switch t.Kind() {
+	default:
+		return res, fmt.Errorf("unsupported type %s", t)
+
+	case reflect.String:
+		res.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return res, err
+		}
+		res.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return res, err
+		}
+		res.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return res, err
+		}
+		res.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return res, err
+		}
+		res.SetFloat(f)
+
+	case reflect.Slice:
+		var items []string
+		if s != "" {
+			items = strings.Split(s, "|")
+		}
+		res = reflect.MakeSlice(t, len(items), len(items))
+		for i := range items {
+			item, err := parseDefault(t.Elem(), items[i])
+			if err != nil {
+				return res, err
+			}
+			res.Index(i).Set(item)
+		}
+
+	case reflect.Ptr:
+		elem, err := parseDefault(t.Elem(), s)
+		if err != nil {
+			return res, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		res.Set(ptr)
+	}
+
+	return res, nil
+}
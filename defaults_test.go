@@ -0,0 +1,140 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestV_Validate_default(t *testing.T) {
+	type Z struct {
+		Limit int `validate:"default=20"`
+	}
+	type X struct {
+		S   string        `validate:"default=asc"`
+		I   int           `validate:"default=-3"`
+		U   uint8         `validate:"default=010"`
+		F   float64       `validate:"default=1.5"`
+		B   bool          `validate:"default=true"`
+		D   time.Duration `validate:"default=1m30s"`
+		T   time.Time     `validate:"default=2020-01-02"`
+		L   []string      `validate:"default=a|b|c"`
+		P   *int          `validate:"default=7"`
+		Z   Z             `validate:"struct"`
+		Set int           `validate:"default=5"`
+	}
+
+	x := X{Set: 1}
+	if errs := make(V).Validate(&x); errs != nil {
+		t.Fatal("unexpected errors:", errs)
+	}
+
+	expected := X{
+		S:   "asc",
+		I:   -3,
+		U:   10,
+		F:   1.5,
+		B:   true,
+		D:   90 * time.Second,
+		T:   time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		L:   []string{"a", "b", "c"},
+		Z:   Z{Limit: 20},
+		Set: 1,
+	}
+	if x.P == nil || *x.P != 7 {
+		t.Fatal("wrong default for a pointer:", x.P)
+	}
+	x.P = nil
+	if !reflect.DeepEqual(x, expected) {
+		t.Fatalf("wrong defaults:\n expected: %+v\n got: %+v", expected, x)
+	}
+}
+
+func TestV_Validate_default_before_validators(t *testing.T) {
+	type X struct {
+		A int `validate:"odd,default=4"`
+	}
+
+	vd := make(V)
+	vd["odd"] = func(i interface{}) interface{} {
+		n := i.(int)
+		if n&1 == 0 {
+			return fmt.Errorf("%d is not odd", n)
+		}
+		return nil
+	}
+
+	/* Passed by value: the field is left alone, validators see the default */
+	errs := vd.Validate(X{})
+	if len(errs) != 1 || errs["A"].(error).Error() != "4 is not odd" {
+		t.Fatal("validators should see the default value; got:", errs)
+	}
+
+	errs = vd.Validate(X{A: 3})
+	if errs != nil {
+		t.Fatal("default should not override a set value; got:", errs)
+	}
+}
+
+func TestV_Validate_default_invalid(t *testing.T) {
+	type X struct {
+		A int  `validate:"default=abc"`
+		B int  `validate:"default=0x10"`
+		C uint `validate:"default=1_000"`
+	}
+
+	x := X{}
+	errs := make(V).Validate(&x)
+	if len(errs) != 3 || errs["A"] == nil || errs["B"] == nil || errs["C"] == nil {
+		t.Fatal("expected errors for invalid defaults; got:", errs)
+	}
+	if x.A != 0 || x.B != 0 || x.C != 0 {
+		t.Fatal("the fields should not be modified:", x)
+	}
+}
+
+func TestV_Validate_default_parens(t *testing.T) {
+	type X struct {
+		A int    `validate:"default(5)"`
+		S string `validate:"default=(none)"`
+		T string `validate:"default((none))"`
+	}
+
+	x := X{}
+	if errs := make(V).Validate(&x); errs != nil {
+		t.Fatal("unexpected errors:", errs)
+	}
+	if x != (X{5, "(none)", "(none)"}) {
+		t.Fatal("wrong defaults:", x)
+	}
+}
+
+func TestV_Var_default(t *testing.T) {
+	vd := make(V)
+	vd["min"] = Param(func(param string) (ValidatorFn, error) {
+		return Rule[int](func(n int) interface{} {
+			if n < 1 {
+				return "too small"
+			}
+			return nil
+		}).Fn(), nil
+	})
+
+	for _, tag := range []string{"default=5,min=1", "min=1,default(5)"} {
+		if e := vd.Var(tag, 0); e != nil {
+			t.Fatalf("unexpected error for %q: %v", tag, e)
+		}
+	}
+	if e := vd.Var("default=0,min=1", 0); e != "too small" {
+		t.Fatal("wrong error:", e)
+	}
+	if e := vd.Var("default=5,min=1", -1); e != "too small" {
+		t.Fatal("default should not override a set value; got:", e)
+	}
+	for _, val := range []interface{}{0, nil} {
+		if e, ok := vd.Var("default=x", val).(Error); !ok || e.Code != "default.invalid" {
+			t.Fatalf("wrong error for %v: %v", val, e)
+		}
+	}
+}
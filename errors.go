@@ -0,0 +1,224 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUndefinedValidator matches, with errors.Is, failures caused by a tag
+	// naming a validator that is not in V.
+	ErrUndefinedValidator = errors.New("undefined validator")
+
+	// ErrInvalidValue matches, with errors.Is, failures caused by the value
+	// of a field, as opposed to a misconfigured tag.
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// configCodes are the codes of failures caused by a misconfigured tag.
+var configCodes = map[string]bool{
+	"validator.undefined": true,
+	"validator.params":    true,
+	"default.invalid":     true,
+	"modifier.type":       true,
+}
+
+// Params holds the values an Error was produced with, e.g. the limits of a
+// range, so clients can build their own messages.
+type Params map[string]interface{}
+
+// Error is a validation failure identified by a stable, machine-readable
+// code such as "string.too_short". Unlike Message, codes and the names of
+// params do not change between releases.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Params  Params `json:"params,omitempty"`
+}
+
+// NewError returns an Error with the given code, message and params.
+func NewError(code, message string, params Params) Error {
+	return Error{Code: code, Message: message, Params: params}
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+// Is makes errors.Is match Errors against ErrUndefinedValidator or
+// ErrInvalidValue according to their code.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrUndefinedValidator:
+		return e.Code == "validator.undefined"
+	case ErrInvalidValue:
+		return !configCodes[e.Code]
+	}
+	return false
+}
+
+// Errors is the result of Validate as an error, for callers that handle
+// validation failures together with other errors:
+//
+//	var verr validate.Errors
+//	if errors.As(err, &verr) {
+//		…
+//	}
+//
+// It unwraps to the failures of the individual fields, so errors.Is
+// matches ErrInvalidValue or ErrUndefinedValidator, and errors.As finds
+// the underlying errors returned by validators.
+type Errors map[string]interface{}
+
+// Check is like Validate, but returns the failures as Errors, or nil.
+func (v V) Check(s interface{}) error {
+	return v.CheckContext(context.Background(), s)
+}
+
+// CheckContext is like ValidateContext, but returns the failures as Errors.
+// If ctx is done before validation has finished, ctx.Err() is returned.
+func (v V) CheckContext(ctx context.Context, s interface{}) error {
+	errs, err := v.ValidateContext(ctx, s)
+	if err != nil {
+		return err
+	}
+	if errs == nil {
+		return nil
+	}
+	return Errors(errs)
+}
+
+// Error lists the messages of all failures, ordered by field path.
+func (e Errors) Error() string {
+	flat := Flatten(e)
+	paths := make([]string, 0, len(flat))
+	for path := range flat {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	msgs := make([]string, len(paths))
+	for i, path := range paths {
+		msgs[i] = path + ": " + flat[path].Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+	res := make([]error, 0, len(e))
+	for name, err := range e {
+		res = append(res, fieldError{name, err})
+	}
+	return res
+}
+
+// fieldError exposes the failure of a single field to errors.Is and
+// errors.As.
+type fieldError struct {
+	name string
+	err  interface{}
+}
+
+func (e fieldError) Error() string {
+	return e.name + ": " + fmt.Sprint(e.err)
+}
+
+func (e fieldError) Is(target error) bool {
+	if target != ErrInvalidValue {
+		return false
+	}
+	switch e.err.(type) {
+	case nil, Error, map[string]interface{}:
+		/* Left to the unwrapped errors */
+		return false
+	case error:
+		return true
+	}
+	switch reflect.ValueOf(e.err).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return false
+	}
+	return true
+}
+
+func (e fieldError) Unwrap() []error {
+	switch err := e.err.(type) {
+	case nil:
+		return nil
+	case error:
+		return []error{err}
+	case map[string]interface{}:
+		return []error{Errors(err)}
+	}
+
+	var res []error
+	val := reflect.ValueOf(e.err)
+	switch val.Kind() {
+	case reflect.Map:
+		for _, k := range val.MapKeys() {
+			name := e.name + "." + fmt.Sprint(k.Interface())
+			res = append(res, fieldError{name, val.MapIndex(k).Interface()})
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if elem := val.Index(i).Interface(); elem != nil {
+				res = append(res, fieldError{e.name + "." + strconv.Itoa(i), elem})
+			}
+		}
+	}
+	return res
+}
+
+// CodeInvalid is the code Flatten reports for failures that are not an Error.
+const CodeInvalid = "invalid"
+
+// Flatten turns the (possibly nested) result of Validate into a flat map
+// from field paths to Errors. Nested structs are joined with a dot and
+// element-wise errors of arrays get the index appended, e.g. "tags.2".
+// Failures that are not an Error, e.g. plain strings or errors returned by
+// user-defined validators, get the code CodeInvalid.
+func Flatten(errs map[string]interface{}) map[string]Error {
+	if len(errs) == 0 {
+		return nil
+	}
+	res := make(map[string]Error)
+	for name, e := range errs {
+		flatten(res, name, e)
+	}
+	return res
+}
+
+func flatten(res map[string]Error, path string, e interface{}) {
+	switch e := e.(type) {
+	case nil:
+		return
+	case Error:
+		res[path] = e
+		return
+	case error:
+		res[path] = Error{Code: CodeInvalid, Message: e.Error()}
+		return
+	case string:
+		res[path] = Error{Code: CodeInvalid, Message: e}
+		return
+	}
+
+	val := reflect.ValueOf(e)
+	switch val.Kind() {
+	case reflect.Map:
+		for _, k := range val.MapKeys() {
+			flatten(res, path+"."+fmt.Sprint(k.Interface()), val.MapIndex(k).Interface())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			flatten(res, path+"."+strconv.Itoa(i), val.Index(i).Interface())
+		}
+	default:
+		res[path] = Error{Code: CodeInvalid, Message: fmt.Sprint(e)}
+	}
+}
@@ -0,0 +1,165 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	tooShort := NewError("string.too_short", "Minimum length is 3", Params{"min": 3})
+	errs := map[string]interface{}{
+		"a": tooShort,
+		"b": errors.New("plain error"),
+		"c": map[string]interface{}{
+			"d": "a message",
+		},
+		"tags": []interface{}{nil, tooShort},
+		"ids":  map[int]interface{}{4: tooShort},
+	}
+
+	expected := map[string]Error{
+		"a":      tooShort,
+		"b":      {Code: CodeInvalid, Message: "plain error"},
+		"c.d":    {Code: CodeInvalid, Message: "a message"},
+		"tags.1": tooShort,
+		"ids.4":  tooShort,
+	}
+	if res := Flatten(errs); !reflect.DeepEqual(res, expected) {
+		t.Fatalf("wrong result:\n expected: %v\n got: %v", expected, res)
+	}
+
+	if res := Flatten(nil); res != nil {
+		t.Fatal("expected nil for no errors; got:", res)
+	}
+}
+
+func TestError_json(t *testing.T) {
+	b, err := json.Marshal(map[string]interface{}{
+		"a": NewError("string.too_short", "Minimum length is 3", Params{"min": 3}),
+		"b": NewError("string.empty", "Should be nonempty", nil),
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := `{"a":{"code":"string.too_short","message":"Minimum length is 3","params":{"min":3}},` +
+		`"b":{"code":"string.empty","message":"Should be nonempty"}}`
+	if string(b) != expected {
+		t.Fatalf("wrong JSON:\n expected: %s\n got: %s", expected, b)
+	}
+}
+
+func TestV_Validate_undef_code(t *testing.T) {
+	type X struct {
+		A string `validate:"oops"`
+	}
+
+	errs := make(V).Validate(X{})
+	e, ok := errs["A"].(Error)
+	if !ok || e.Code != "validator.undefined" || e.Params["name"] != "oops" {
+		t.Fatal("wrong error for an undefined validator:", errs)
+	}
+}
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return "code " + strconv.Itoa(e.code)
+}
+
+func TestV_Check(t *testing.T) {
+	type Z struct {
+		B int `json:"b" validate:"coded"`
+	}
+	type X struct {
+		A string `json:"a" validate:"short"`
+		Z Z      `json:"z" validate:"struct"`
+	}
+
+	vd := modifierValidator()
+	vd["coded"] = func(i interface{}) interface{} {
+		if i.(int) != 0 {
+			return &codeError{i.(int)}
+		}
+		return nil
+	}
+
+	if err := vd.Check(X{A: "abc"}); err != nil {
+		t.Fatal("unexpected error for a valid struct:", err)
+	}
+
+	err := vd.Check(X{A: "abcdef", Z: Z{B: 422}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if err.Error() != `a: "abcdef" is too long; z.b: code 422` {
+		t.Fatal("wrong message:", err)
+	}
+
+	var verrs Errors
+	if !errors.As(err, &verrs) || len(verrs) != 2 {
+		t.Fatal("errors.As should find Errors:", err)
+	}
+	if !errors.As(err, &Errors{}) {
+		t.Fatal("errors.As should accept a pointer to a literal")
+	}
+
+	var cerr *codeError
+	if !errors.As(err, &cerr) || cerr.code != 422 {
+		t.Fatal("errors.As should find the nested validator error:", err)
+	}
+
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatal("validation failures should match ErrInvalidValue")
+	}
+	if errors.Is(err, ErrUndefinedValidator) {
+		t.Fatal("validation failures should not match ErrUndefinedValidator")
+	}
+}
+
+func TestV_Check_undef(t *testing.T) {
+	type X struct {
+		A string `validate:"oops"`
+	}
+
+	err := make(V).Check(X{})
+	if !errors.Is(err, ErrUndefinedValidator) {
+		t.Fatal("undefined validators should match ErrUndefinedValidator:", err)
+	}
+	if errors.Is(err, ErrInvalidValue) {
+		t.Fatal("undefined validators should not match ErrInvalidValue:", err)
+	}
+
+	var e Error
+	if !errors.As(err, &e) || e.Code != "validator.undefined" {
+		t.Fatal("errors.As should find the Error:", err)
+	}
+}
+
+func TestV_Check_elementwise(t *testing.T) {
+	type X struct {
+		A []string `validate:"each"`
+	}
+
+	vd := make(V)
+	vd["each"] = func(i interface{}) interface{} {
+		return []interface{}{nil, "bad", NewError("x.y", "coded", nil)}
+	}
+
+	err := vd.Check(X{})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatal("element-wise failures should match ErrInvalidValue:", err)
+	}
+	var e Error
+	if !errors.As(err, &e) || e.Code != "x.y" {
+		t.Fatal("errors.As should find the element's Error:", err)
+	}
+	if err.Error() != "A.1: bad; A.2: coded" {
+		t.Fatal("wrong message:", err)
+	}
+}
@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// modified wraps the new value of a field returned by a modifier.
+type modified struct {
+	val interface{}
+}
+
+// Modified is returned by a validator that normalizes a field instead of
+// checking it. Validate replaces the field's value with val before running
+// the remaining validators named in the tag. When the struct was passed by
+// pointer, the new value is also written back to the field.
+//
+// Values presented by a ValueMapper are never written back.
+func Modified(val interface{}) interface{} {
+	return modified{val}
+}
+
+// Modifier adapts fn into a validator that always replaces the field's value
+// with the result of fn. See Modified.
+func Modifier(fn func(interface{}) interface{}) ValidatorFn {
+	return func(i interface{}) interface{} {
+		return Modified(fn(i))
+	}
+}
+
+// setValue stores val into the settable field fv. Values of a different
+// type with the same kind, e.g. a string for a named string type, are
+// converted.
+func setValue(fv reflect.Value, val interface{}) error {
+	rv := reflect.ValueOf(val)
+	if !rv.IsValid() {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	switch {
+	case rv.Type().AssignableTo(fv.Type()):
+		fv.Set(rv)
+	case rv.Kind() == fv.Kind() && rv.Type().ConvertibleTo(fv.Type()):
+		fv.Set(rv.Convert(fv.Type()))
+	default:
+		return NewError("modifier.type",
+			fmt.Sprintf("cannot assign %T to a field of type %s", val, fv.Type()),
+			Params{"type": fv.Type().String()})
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func modifierValidator() V {
+	vd := make(V)
+	vd["trim"] = Modifier(func(i interface{}) interface{} {
+		return strings.TrimSpace(i.(string))
+	})
+	vd["short"] = func(i interface{}) interface{} {
+		s := i.(string)
+		if len(s) >= 5 {
+			return fmt.Errorf("%q is too long", s)
+		}
+		return nil
+	}
+	vd["int"] = func(i interface{}) interface{} {
+		return Modified(len(i.(string)))
+	}
+	return vd
+}
+
+func TestV_Validate_Modified(t *testing.T) {
+	type X struct {
+		A string `validate:"trim,short"`
+		B string `validate:"short"`
+	}
+
+	vd := modifierValidator()
+
+	x := X{A: "  abc  ", B: "  abc  "}
+	errs := vd.Validate(&x)
+	if len(errs) != 1 || errs["B"] == nil {
+		t.Fatal("wrong errors: expected an error for B only; got:", errs)
+	}
+	if x.A != "abc" {
+		t.Fatalf("modified value was not written back: %q", x.A)
+	}
+	if x.B != "  abc  " {
+		t.Fatalf("unmodified field has changed: %q", x.B)
+	}
+}
+
+func TestV_Validate_Modified_byvalue(t *testing.T) {
+	type X struct {
+		A string `validate:"trim,short"`
+	}
+
+	vd := modifierValidator()
+
+	x := X{A: "  abc  "}
+	if errs := vd.Validate(x); errs != nil {
+		t.Fatal("validators should see the modified value; got:", errs)
+	}
+	if x.A != "  abc  " {
+		t.Fatalf("a struct passed by value has changed: %q", x.A)
+	}
+}
+
+func TestV_Validate_Modified_nested(t *testing.T) {
+	type Name string
+	type Z struct {
+		B Name `validate:"trim"`
+	}
+	type X struct {
+		A Z  `validate:"struct"`
+		P *Z `validate:"struct"`
+	}
+
+	vd := modifierValidator()
+	vd["trim"] = Modifier(func(i interface{}) interface{} {
+		return strings.TrimSpace(string(i.(Name)))
+	})
+
+	x := X{A: Z{B: " a "}, P: &Z{B: " b "}}
+	if errs := vd.Validate(&x); errs != nil {
+		t.Fatal("unexpected errors:", errs)
+	}
+	if x.A.B != "a" || x.P.B != "b" {
+		t.Fatalf("nested fields were not modified: %q, %q", x.A.B, x.P.B)
+	}
+}
+
+func TestV_Validate_Modified_mismatch(t *testing.T) {
+	type X struct {
+		A string `validate:"int,short"`
+	}
+
+	vd := modifierValidator()
+
+	x := X{A: "abc"}
+	errs := vd.Validate(&x)
+	if len(errs) != 1 || errs["A"] == nil {
+		t.Fatal("expected an error for A; got:", errs)
+	}
+	if errs["A"].(error).Error() != "cannot assign int to a field of type string" {
+		t.Fatal("wrong error for A:", errs["A"])
+	}
+}
@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"strings"
+	"sync"
+)
+
+// paramCall is a pending call of a parameterized validator, completed by
+// the engine with the parameter given in the tag.
+type paramCall struct {
+	build func(param string) (ValidatorFn, error)
+}
+
+type builtParam struct {
+	fn  ValidatorFn
+	err error
+}
+
+// Param adapts fn, a constructor of validators, so that it can be
+// registered in V and given a parameter in tags, either as `name=param` or
+// as `name(param)`:
+//
+//	vd["min"] = validate.Param(func(param string) (validate.ValidatorFn, error) {
+//		…
+//	})
+//
+//	type X struct {
+//		A int `validate:"min=5"`
+//	}
+//
+// A tag naming the validator without a parameter calls fn with an empty
+// string. fn is called once for every distinct parameter; invalid
+// parameters should be reported by returning an error.
+//
+// The adapted function only works through Validate and Var; calling it
+// directly does not run fn.
+func Param(fn func(param string) (ValidatorFn, error)) ValidatorFn {
+	var cache sync.Map
+	call := paramCall{func(param string) (ValidatorFn, error) {
+		if b, ok := cache.Load(param); ok {
+			return b.(builtParam).fn, b.(builtParam).err
+		}
+		vf, err := fn(param)
+		cache.Store(param, builtParam{vf, err})
+		return vf, err
+	}}
+
+	return func(interface{}) interface{} {
+		return call
+	}
+}
+
+// splitParam splits a tag entry of the form `name=param` or `name(param)`.
+func splitParam(vt string) (name, param string, ok bool) {
+	if strings.HasSuffix(vt, ")") {
+		if i := strings.IndexByte(vt, '('); i > 0 {
+			return vt[:i], vt[i+1 : len(vt)-1], true
+		}
+	}
+	if i := strings.IndexByte(vt, '='); i > 0 {
+		return vt[:i], vt[i+1:], true
+	}
+	return vt, "", false
+}
@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func paramValidator(builds *int) V {
+	vd := make(V)
+	vd["min"] = Param(func(param string) (ValidatorFn, error) {
+		*builds++
+		min, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		return func(i interface{}) interface{} {
+			if n := i.(int); n < min {
+				return fmt.Errorf("%d is less than %d", n, min)
+			}
+			return nil
+		}, nil
+	})
+	vd["odd"] = batchValidator()["odd"]
+	return vd
+}
+
+func TestV_Validate_Param(t *testing.T) {
+	type X struct {
+		A int `validate:"min=5"`
+		B int `validate:"min(10),odd"`
+		C int `validate:"min=5"`
+	}
+
+	builds := 0
+	vd := paramValidator(&builds)
+
+	if errs := vd.Validate(X{A: 5, B: 11, C: 6}); errs != nil {
+		t.Fatal("unexpected errors:", errs)
+	}
+	errs := vd.Validate(X{A: 4, B: 9, C: 5})
+	if len(errs) != 2 {
+		t.Fatal("wrong number of errors: expected 2; got:", errs)
+	}
+	if errs["A"].(error).Error() != "4 is less than 5" {
+		t.Fatal("wrong error for A:", errs["A"])
+	}
+	if errs["B"].(error).Error() != "9 is less than 10" {
+		t.Fatal("wrong error for B:", errs["B"])
+	}
+	if builds != 2 {
+		t.Fatal("validators should be built once per parameter; got:", builds)
+	}
+}
+
+func TestV_Validate_Param_invalid(t *testing.T) {
+	type X struct {
+		A int `validate:"min=x"`
+		B int `validate:"odd=3"`
+		C int `validate:"max=3"`
+	}
+
+	builds := 0
+	vd := paramValidator(&builds)
+
+	errs := vd.Validate(X{})
+	if len(errs) != 3 {
+		t.Fatal("wrong number of errors: expected 3; got:", errs)
+	}
+	if e := errs["A"].(Error); e.Code != "validator.params" {
+		t.Fatal("wrong error for A:", e)
+	}
+	if e := errs["B"].(Error); e.Code != "validator.params" || e.Message != `validator "odd" does not take parameters` {
+		t.Fatal("wrong error for B:", e)
+	}
+	if e := errs["C"].(Error); e.Code != "validator.undefined" || e.Message != `undefined validator: "max=3"` {
+		t.Fatal("wrong error for C:", e)
+	}
+
+	if err := vd.Check(X{}); errors.Is(err, ErrInvalidValue) {
+		t.Fatal("misconfigured tags should not match ErrInvalidValue:", err)
+	}
+}
+
+func TestV_Var(t *testing.T) {
+	builds := 0
+	vd := paramValidator(&builds)
+	vd["inc"] = Modifier(func(i interface{}) interface{} {
+		return i.(int) + 1
+	})
+
+	if e := vd.Var("min=3,odd", 5); e != nil {
+		t.Fatal("unexpected error:", e)
+	}
+	if e := vd.Var("min=3,odd", 1); e == nil || e.(error).Error() != "1 is less than 3" {
+		t.Fatal("wrong error:", e)
+	}
+	if e := vd.Var("inc,odd", 4); e != nil {
+		t.Fatal("modifiers should apply to the rest of the tag:", e)
+	}
+}
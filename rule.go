@@ -0,0 +1,159 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Rule is a validator for values of a single type T. Unlike a plain
+// ValidatorFn it does not need to check the type of its argument:
+//
+//	nonempty := validate.Rule[string](func(s string) interface{} {
+//		if s == "" {
+//			return "Should be nonempty"
+//		}
+//		return nil
+//	})
+//	vd["nonempty"] = nonempty.Fn()
+//
+// A Rule used on a field of another type fails when it runs, as a failure
+// of that field; V.CheckType reports it without validating any value.
+type Rule[T any] func(T) interface{}
+
+// Fn adapts r into a ValidatorFn. Values of type T are passed to r as is;
+// values of a named type with the same underlying kind as T are converted,
+// and pointers are followed. Nil pointers are valid: they hold no value to
+// check, and requiring one is left to validators like notnull.
+//
+// Values of any other type fail with typeErr, if given, or with an Error
+// describing the expected type.
+func (r Rule[T]) Fn(typeErr ...interface{}) ValidatorFn {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	mismatch := typeError(typ, typeErr)
+
+	return Typed(func(src interface{}) interface{} {
+		t, ok, isNil := convert[T](typ, src)
+		if isNil {
+			return nil
+		}
+		if !ok {
+			return mismatch
+		}
+		return r(t)
+	}, func(t reflect.Type) bool {
+		return convertible(t, typ)
+	})
+}
+
+// Strings adapts r into a ValidatorFn for all the ways text is held in
+// fields: strings (including named string types), byte arrays and arrays of
+// strings. Arrays are checked element-wise: the result is nil if all the
+// elements are valid and otherwise has the failure of every element at its
+// index.
+//
+// Values of any other type fail with typeErr, if given, or with an Error
+// with the code "string.type".
+func Strings(r Rule[string], typeErr ...interface{}) ValidatorFn {
+	mismatch := interface{}(NewError("string.type", "Should be a string or byte array", nil))
+	if len(typeErr) > 0 {
+		mismatch = typeErr[0]
+	}
+	str := r.Fn(mismatch)
+
+	return Typed(func(src interface{}) interface{} {
+		switch src.(type) {
+		case []byte:
+			return r(string(src.([]byte)))
+		case []string:
+			arr := src.([]string)
+			errArr := make([]interface{}, len(arr))
+			failed := false
+			for i := range arr {
+				if errArr[i] = r(arr[i]); errArr[i] != nil {
+					failed = true
+				}
+			}
+			if failed {
+				return errArr
+			}
+			return nil
+		}
+		return str(src)
+	}, func(t reflect.Type) bool {
+		return t == bytesType || t == stringsType || Accepts(str, t)
+	})
+}
+
+// Struct validates t, which must be a struct or a pointer to one, and
+// returns its failures as Errors, or nil. Unlike V.Check, which treats any
+// other value as valid, Struct returns an error when T is not a struct.
+// The tags of T are checked when they are used; see V.CheckType to check
+// them beforehand.
+func Struct[T any](v V, t T) error {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: Struct expects a struct, got %s", typ)
+	}
+	return v.Check(t)
+}
+
+// convert returns src as a T. isNil is set for nil pointers.
+func convert[T any](typ reflect.Type, src interface{}) (t T, ok, isNil bool) {
+	if t, ok := src.(T); ok {
+		return t, true, false
+	}
+
+	val := reflect.ValueOf(src)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return t, false, true
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return t, false, false
+	}
+
+	switch {
+	case val.Type().AssignableTo(typ):
+	case val.Kind() == typ.Kind() && val.Type().ConvertibleTo(typ):
+		val = val.Convert(typ)
+	default:
+		return t, false, false
+	}
+	return val.Interface().(T), true, false
+}
+
+// convertible reports whether convert takes values of type t as a typ.
+func convertible(t, typ reflect.Type) bool {
+	if t.AssignableTo(typ) {
+		return true
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.AssignableTo(typ) || t.Kind() == typ.Kind() && t.ConvertibleTo(typ)
+}
+
+// typeError returns the failure of values that are not of type typ.
+func typeError(typ reflect.Type, typeErr []interface{}) interface{} {
+	if len(typeErr) > 0 {
+		return typeErr[0]
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return NewError("string.type", "Should be a string", nil)
+	case reflect.Bool:
+		return NewError("bool.type", "Should be a boolean", nil)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return NewError("number.type", "Should be a number", nil)
+	}
+	return NewError("type.mismatch", "Should be of type "+typ.String(),
+		Params{"type": typ.String()})
+}
@@ -0,0 +1,95 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRule_Fn(t *testing.T) {
+	type Cents int64
+	type Name string
+
+	odd := Rule[int64](func(n int64) interface{} {
+		if n&1 == 0 {
+			return fmt.Errorf("%d is not odd", n)
+		}
+		return nil
+	}).Fn()
+
+	n := int64(3)
+	var nilPtr *int64
+	for _, ok := range []interface{}{int64(1), Cents(5), &n, nilPtr} {
+		if e := odd(ok); e != nil {
+			t.Fatalf("unexpected error for %T: %v", ok, e)
+		}
+	}
+	if e := odd(Cents(4)); e == nil || e.(error).Error() != "4 is not odd" {
+		t.Fatal("wrong error for an even number:", e)
+	}
+
+	typeErr := NewError("number.type", "Should be a number", nil)
+	for _, bad := range []interface{}{nil, 1, "1", 1.0, []int64{1}} {
+		if e := odd(bad); !reflect.DeepEqual(e, typeErr) {
+			t.Fatalf("wrong error for %T: %v", bad, e)
+		}
+	}
+
+	named := Rule[Name](func(n Name) interface{} { return nil })
+	if e := named.Fn()(1); !reflect.DeepEqual(e, NewError("string.type", "Should be a string", nil)) {
+		t.Fatal("wrong error for a named string type:", e)
+	}
+	if e := named.Fn("custom")(1); e != "custom" {
+		t.Fatal("wrong custom error:", e)
+	}
+
+	type S struct{ A int }
+	st := Rule[S](func(S) interface{} { return nil }).Fn()
+	if e := st(1); !reflect.DeepEqual(e, NewError("type.mismatch",
+		"Should be of type validate.S", Params{"type": "validate.S"})) {
+		t.Fatal("wrong error for a struct type:", e)
+	}
+}
+
+func TestStrings(t *testing.T) {
+	type Name string
+
+	short := Strings(func(s string) interface{} {
+		if len(s) >= 3 {
+			return "too long"
+		}
+		return nil
+	})
+
+	for _, ok := range []interface{}{"ab", []byte("ab"), Name("ab"), []string{"a", "b"}, []string{}} {
+		if e := short(ok); e != nil {
+			t.Fatalf("unexpected error for %T: %v", ok, e)
+		}
+	}
+	if e := short("abc"); e != "too long" {
+		t.Fatal("wrong error:", e)
+	}
+	if e := short([]string{"a", "abc"}); !reflect.DeepEqual(e, []interface{}{nil, "too long"}) {
+		t.Fatal("wrong element-wise errors:", e)
+	}
+	if e := short(1); !reflect.DeepEqual(e, NewError("string.type", "Should be a string or byte array", nil)) {
+		t.Fatal("wrong type error:", e)
+	}
+}
+
+func TestStruct(t *testing.T) {
+	type X struct {
+		A int `validate:"odd"`
+	}
+
+	vd := batchValidator()
+	if err := Struct(vd, X{A: 1}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := Struct(vd, &X{A: 2}); err == nil || err.Error() != "A: 2 is not odd" {
+		t.Fatal("wrong error:", err)
+	}
+	if err := Struct(vd, 7); err == nil || err.Error() != "validate: Struct expects a struct, got int" {
+		t.Fatal("expected an error for a non-struct:", err)
+	}
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// StreamResult describes a single record read by ValidateStream.
+type StreamResult struct {
+	// Index is the position of the record in the stream, starting at 0.
+	Index int
+	// Offset is the byte offset of the first byte of the record.
+	Offset int64
+	// Line is the line the record starts on, starting at 1.
+	Line int
+	// Value is a pointer to the decoded record.
+	Value interface{}
+	// Err is set when the record is well-formed JSON that could not be
+	// decoded into the target type. Errors is nil in that case.
+	Err error
+	// Errors holds the result of Validate for the record.
+	Errors map[string]interface{}
+}
+
+// ValidateStream reads records from r one at a time and validates each of
+// them. The input is either a single JSON array or newline-delimited JSON
+// (any sequence of whitespace-separated values); the format is detected from
+// the first non-space byte.
+//
+// Every record is decoded into a new value of the same type as proto and fn is
+// called with the result, whether the record is valid or not. Only one record
+// is held in memory at a time. If fn returns an error, reading stops and that
+// error is returned. Malformed JSON also stops reading, as does anything but
+// whitespace after an array.
+func (v V) ValidateStream(r io.Reader, proto interface{}, fn func(StreamResult) error) error {
+	typ := reflect.TypeOf(proto)
+	if typ == nil {
+		return errors.New("validate: ValidateStream needs a non-nil prototype")
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	lines := &lineReader{r: r}
+	br := bufio.NewReader(lines)
+	skipped, array, err := skipSpace(br)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(br)
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; ; i++ {
+		if array && !dec.More() {
+			break
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF && !array {
+			break
+		} else if err != nil {
+			return fmt.Errorf("validate: record %d: %w", i, err)
+		}
+
+		start := skipped + dec.InputOffset() - int64(len(raw))
+		res := StreamResult{
+			Index:  i,
+			Offset: start,
+			Line:   lines.lineAt(start),
+			Value:  reflect.New(typ).Interface(),
+		}
+		if res.Err = json.Unmarshal(raw, res.Value); res.Err == nil {
+			res.Errors = v.Validate(res.Value)
+		}
+		if err := fn(res); err != nil {
+			return err
+		}
+	}
+
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		/* Only whitespace may follow the array */
+		if _, err := dec.Token(); err != io.EOF {
+			if err == nil {
+				err = errors.New("unexpected value")
+			}
+			return fmt.Errorf("validate: after the array: %w", err)
+		}
+	}
+	return nil
+}
+
+// skipSpace consumes the whitespace at the start of br. It returns the
+// number of bytes skipped, so that offsets can be made relative to the
+// input again, and whether the first other byte is '['.
+func skipSpace(br *bufio.Reader) (skipped int64, array bool, err error) {
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			return skipped, false, nil
+		}
+		if err != nil {
+			return skipped, false, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			skipped++
+			continue
+		}
+		return skipped, b == '[', br.UnreadByte()
+	}
+}
+
+// lineReader counts the newlines passing through it so that line numbers
+// can be recovered for byte offsets without keeping the input around.
+type lineReader struct {
+	r io.Reader
+	// n is the number of bytes read so far.
+	n int64
+	// newlines holds offsets of newlines that have not been passed yet.
+	newlines []int64
+	// line is the number of newlines passed so far.
+	line int
+}
+
+func (l *lineReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			l.newlines = append(l.newlines, l.n+int64(i))
+		}
+	}
+	l.n += int64(n)
+	return n, err
+}
+
+// lineAt returns the line of the byte at offset off. Offsets must not
+// decrease between calls.
+func (l *lineReader) lineAt(off int64) int {
+	i := 0
+	for i < len(l.newlines) && l.newlines[i] < off {
+		i++
+	}
+	l.line += i
+	l.newlines = l.newlines[i:]
+	return l.line + 1
+}
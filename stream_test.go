@@ -0,0 +1,161 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type streamItem struct {
+	A int `json:"a" validate:"odd"`
+}
+
+func collectStream(t *testing.T, input string) []StreamResult {
+	var res []StreamResult
+	err := batchValidator().ValidateStream(strings.NewReader(input), streamItem{},
+		func(r StreamResult) error {
+			res = append(res, r)
+			return nil
+		})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return res
+}
+
+func TestV_ValidateStream_ndjson(t *testing.T) {
+	input := "{\"a\":1}\n{\"a\":2}\n\n{\"a\":\"x\"}\n  {\"a\":4}\n"
+	res := collectStream(t, input)
+
+	if len(res) != 4 {
+		t.Fatal("wrong number of records: expected 4; got:", res)
+	}
+	if res[0].Errors != nil || res[0].Err != nil || res[0].Value.(*streamItem).A != 1 {
+		t.Fatal("wrong result for a valid record:", res[0])
+	}
+	if res[1].Errors["a"].(error).Error() != "2 is not odd" {
+		t.Fatal("wrong errors for record 1:", res[1])
+	}
+	if res[2].Err == nil || res[2].Errors != nil {
+		t.Fatal("expected a decoding error for record 2:", res[2])
+	}
+	if res[3].Errors["a"] == nil {
+		t.Fatal("expected errors for record 3:", res[3])
+	}
+
+	for i, want := range []struct {
+		offset int64
+		line   int
+	}{{0, 1}, {8, 2}, {17, 4}, {29, 5}} {
+		if res[i].Index != i || res[i].Offset != want.offset || res[i].Line != want.line {
+			t.Fatalf("wrong position for record %d: expected %d@%d; got: %d@%d",
+				i, want.offset, want.line, res[i].Offset, res[i].Line)
+		}
+		if input[res[i].Offset] != '{' {
+			t.Fatalf("offset for record %d does not point at the record: %d", i, res[i].Offset)
+		}
+	}
+}
+
+func TestV_ValidateStream_array(t *testing.T) {
+	input := "[\n  {\"a\": 3},\n  {\"a\": 6}\n]\n"
+	res := collectStream(t, input)
+
+	if len(res) != 2 {
+		t.Fatal("wrong number of records: expected 2; got:", res)
+	}
+	if res[0].Errors != nil || res[0].Line != 2 || res[0].Offset != 4 {
+		t.Fatal("wrong result for record 0:", res[0])
+	}
+	if res[1].Errors["a"] == nil || res[1].Line != 3 || res[1].Offset != 16 {
+		t.Fatal("wrong result for record 1:", res[1])
+	}
+
+	if res := collectStream(t, " [ ] "); len(res) != 0 {
+		t.Fatal("unexpected records in an empty array:", res)
+	}
+	if res := collectStream(t, ""); len(res) != 0 {
+		t.Fatal("unexpected records in an empty stream:", res)
+	}
+}
+
+func TestV_ValidateStream_leading_whitespace(t *testing.T) {
+	/* More whitespace than fits in the read buffer */
+	space := strings.Repeat(" \n", 5000)
+
+	for _, input := range []string{space + "{\"a\":1}\n{\"a\":3}", space + "[{\"a\":1},\n{\"a\":3}]"} {
+		res := collectStream(t, input)
+		if len(res) != 2 {
+			t.Fatal("wrong number of records: expected 2; got:", res)
+		}
+		for i, r := range res {
+			if input[r.Offset] != '{' || r.Line != 5001+i || r.Errors != nil {
+				t.Fatalf("wrong result for record %d: %+v", i, r)
+			}
+		}
+	}
+
+	if res := collectStream(t, space); len(res) != 0 {
+		t.Fatal("unexpected records in a blank stream:", res)
+	}
+}
+
+func TestV_ValidateStream_stop(t *testing.T) {
+	stop := errors.New("stop")
+	n := 0
+	err := batchValidator().ValidateStream(strings.NewReader(`[{"a":1},{"a":2},{"a":3}]`), &streamItem{},
+		func(r StreamResult) error {
+			n++
+			if r.Errors != nil {
+				return stop
+			}
+			return nil
+		})
+	if err != stop {
+		t.Fatal("wrong error: expected the callback's error; got:", err)
+	}
+	if n != 2 {
+		t.Fatal("wrong number of callbacks: expected 2; got:", n)
+	}
+}
+
+func TestV_ValidateStream_malformed(t *testing.T) {
+	err := batchValidator().ValidateStream(strings.NewReader("{\"a\":1}\n{\"a\":"), streamItem{},
+		func(r StreamResult) error { return nil })
+	if err == nil {
+		t.Fatal("expected an error for malformed input")
+	}
+}
+
+func TestV_ValidateStream_trailing(t *testing.T) {
+	for _, input := range []string{`[{"a":1}] garbage`, `[{"a":1}] [2]`, `[{"a":1}] {`} {
+		calls := 0
+		err := batchValidator().ValidateStream(strings.NewReader(input), streamItem{},
+			func(r StreamResult) error {
+				calls++
+				return nil
+			})
+		if err == nil || calls != 1 {
+			t.Fatalf("expected an error after the records of %q; got: %v (%d calls)", input, err, calls)
+		}
+	}
+
+	var syntaxErr *json.SyntaxError
+	err := batchValidator().ValidateStream(strings.NewReader(`[{"a":1}] garbage`), streamItem{},
+		func(r StreamResult) error { return nil })
+	if !errors.As(err, &syntaxErr) {
+		t.Fatal("expected a *json.SyntaxError; got:", err)
+	}
+
+	err = batchValidator().ValidateStream(strings.NewReader(`{"a":1} {"a":tru}`), streamItem{},
+		func(r StreamResult) error { return nil })
+	if !errors.As(err, &syntaxErr) {
+		t.Fatal("expected a *json.SyntaxError; got:", err)
+	}
+
+	res := collectStream(t, "[{\"a\":1}]\n\t \n")
+	if len(res) != 1 {
+		t.Fatal("trailing whitespace should be accepted:", res)
+	}
+}
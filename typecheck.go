@@ -0,0 +1,145 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// typeProbe is passed to validators by CheckType instead of a value. Typed
+// validators answer it with a typeAnswer rather than checking it.
+type typeProbe struct {
+	typ reflect.Type /* nil when the type is not known */
+}
+
+type typeAnswer struct {
+	ok bool
+}
+
+var (
+	bytesType          = reflect.TypeOf([]byte(nil))
+	stringsType        = reflect.TypeOf([]string(nil))
+	valueValidatorType = reflect.TypeOf((*ValueValidator)(nil)).Elem()
+	valueMapperType    = reflect.TypeOf((*ValueMapper)(nil)).Elem()
+)
+
+// Typed adapts fn so that CheckType knows the types of the fields it
+// applies to: accepts reports whether fn takes values of type t. Validators
+// built with Rule and Strings are typed already; Typed is for the others:
+//
+//	vd["even"] = validate.Typed(even, func(t reflect.Type) bool {
+//		return t.Kind() == reflect.Int
+//	})
+func Typed(fn ValidatorFn, accepts func(t reflect.Type) bool) ValidatorFn {
+	return func(src interface{}) interface{} {
+		if p, ok := src.(typeProbe); ok {
+			return typeAnswer{p.typ == nil || accepts(p.typ)}
+		}
+		return fn(src)
+	}
+}
+
+// Accepts reports whether fn takes values of type t. Validators that are
+// not typed, see Typed, are taken to accept any type.
+func Accepts(fn ValidatorFn, t reflect.Type) bool {
+	a, ok := probe(fn, t).(typeAnswer)
+	return !ok || a.ok
+}
+
+// probe calls fn with a typeProbe for t. Untyped validators do not expect
+// it, so a panic is taken as no answer.
+func probe(fn ValidatorFn, t reflect.Type) (e interface{}) {
+	defer func() {
+		if recover() != nil {
+			e = nil
+		}
+	}()
+	return fn(typeProbe{t})
+}
+
+// CheckType checks the tags of the struct type t, or of the struct type t
+// points to, against v without validating any value: every validator must
+// be defined, take its parameter if it is given one and, if it is typed,
+// accept the type of its field. Nested structs tagged "struct" are checked
+// too. Validate reports the same problems when it runs, as failures of the
+// fields; CheckType reports them once, when the validators are set up:
+//
+//	if err := vd.CheckType(reflect.TypeOf(Order{})); err != nil {
+//		panic(err)
+//	}
+//
+// Untyped validators are called once with a placeholder value, which they
+// should fail like any value of an unexpected type. Fields of interface
+// types or presented by a ValueMapper are only checked for the names and
+// parameters of their validators, and fields validated by a ValueValidator
+// are skipped.
+func (v V) CheckType(t reflect.Type) error {
+	return v.checkType(t, "", map[reflect.Type]bool{})
+}
+
+func (v V) checkType(t reflect.Type, path string, seen map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("validate")
+		if f.PkgPath != "" || tag == "" || f.Type.Implements(valueValidatorType) {
+			continue
+		}
+		fieldPath := tagName(f)
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		typ := f.Type
+		if typ.Kind() == reflect.Interface || typ.Implements(valueMapperType) {
+			typ = nil
+		}
+
+		vts, _, _ := splitDefault(strings.Split(tag, ","))
+		for _, vt := range vts {
+			if vt == "struct" {
+				if typ != nil {
+					if err := v.checkType(typ, fieldPath, seen); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err := v.checkEntry(vt, typ); err != nil {
+				return fmt.Errorf("validate: field %q: %v", fieldPath, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkEntry checks the tag entry vt of a field of type t, or of an unknown
+// type if t is nil.
+func (v V) checkEntry(vt string, t reflect.Type) error {
+	vf, name, param, hasParam := v.lookup(vt)
+	if vf == nil {
+		return fmt.Errorf("undefined validator: %q", vt)
+	}
+
+	e := probe(vf, t)
+	if p, ok := e.(paramCall); ok {
+		fn, err := p.build(param)
+		if err != nil {
+			return fmt.Errorf("invalid parameters for %q: %v", name, err)
+		}
+		e = probe(fn, t)
+	} else if hasParam {
+		return fmt.Errorf("validator %q does not take parameters", name)
+	}
+
+	if a, ok := e.(typeAnswer); ok && !a.ok {
+		return fmt.Errorf("validator %q does not apply to %s", name, t)
+	}
+	return nil
+}
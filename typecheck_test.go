@@ -0,0 +1,150 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type mappedInt struct{}
+
+func (mappedInt) MapValue() interface{} { return 1 }
+
+func typedValidator() V {
+	vd := make(V)
+	vd["odd"] = Rule[int](func(n int) interface{} {
+		if n&1 == 0 {
+			return "even"
+		}
+		return nil
+	}).Fn()
+	vd["short"] = Strings(func(s string) interface{} { return nil })
+	vd["max"] = Param(func(param string) (ValidatorFn, error) {
+		if param == "" {
+			return nil, fmt.Errorf("missing bound")
+		}
+		return Rule[int](func(int) interface{} { return nil }).Fn(), nil
+	})
+	vd["positive"] = Typed(func(i interface{}) interface{} { return nil }, func(t reflect.Type) bool {
+		return t.Kind() == reflect.Int
+	})
+	/* Untyped, and not expecting anything but ints */
+	vd["legacy"] = func(i interface{}) interface{} {
+		if i.(int) < 0 {
+			return "negative"
+		}
+		return nil
+	}
+	return vd
+}
+
+func TestV_CheckType(t *testing.T) {
+	type Inner struct {
+		N int `validate:"odd"`
+	}
+	type X struct {
+		A int              `validate:"odd,max=10,positive,legacy"`
+		B *int             `validate:"odd"`
+		C string           `validate:"short"`
+		D []byte           `validate:"short"`
+		E []string         `validate:"short"`
+		F *string          `validate:"default=x,short"`
+		G interface{}      `validate:"odd"`
+		H mappedInt        `validate:"odd"`
+		I Inner            `validate:"struct"`
+		J *Inner           `validate:"struct"`
+		K int              `json:"k,omitempty" validate:"odd"`
+		l string           `validate:"odd"`
+		M map[string]Inner `validate:"struct"`
+	}
+
+	vd := typedValidator()
+	for _, typ := range []reflect.Type{reflect.TypeOf(X{}), reflect.TypeOf(&X{})} {
+		if err := vd.CheckType(typ); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	for _, c := range []struct {
+		typ interface{}
+		err string
+	}{
+		{struct {
+			A string `validate:"odd"`
+		}{}, `validate: field "A": validator "odd" does not apply to string`},
+		{struct {
+			A string `json:"a" validate:"max=3"`
+		}{}, `validate: field "a": validator "max" does not apply to string`},
+		{struct {
+			A []int `validate:"short"`
+		}{}, `validate: field "A": validator "short" does not apply to []int`},
+		{struct {
+			A string `validate:"positive"`
+		}{}, `validate: field "A": validator "positive" does not apply to string`},
+		{struct {
+			A int `validate:"max"`
+		}{}, `validate: field "A": invalid parameters for "max": missing bound`},
+		{struct {
+			A int `validate:"odd=1"`
+		}{}, `validate: field "A": validator "odd" does not take parameters`},
+		{struct {
+			A int `validate:"even"`
+		}{}, `validate: field "A": undefined validator: "even"`},
+		{struct {
+			A struct {
+				B string `json:"b" validate:"odd"`
+			} `json:"a" validate:"struct"`
+		}{}, `validate: field "a.b": validator "odd" does not apply to string`},
+	} {
+		err := vd.CheckType(reflect.TypeOf(c.typ))
+		if err == nil || err.Error() != c.err {
+			t.Fatalf("wrong error for %T: expected %q; got: %v", c.typ, c.err, err)
+		}
+	}
+}
+
+type checkNode struct {
+	V    int        `validate:"odd"`
+	Next *checkNode `validate:"struct"`
+}
+
+func TestV_CheckType_recursive(t *testing.T) {
+	if err := typedValidator().CheckType(reflect.TypeOf(checkNode{})); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestAccepts(t *testing.T) {
+	vd := typedValidator()
+
+	for _, c := range []struct {
+		fn  ValidatorFn
+		typ interface{}
+		ok  bool
+	}{
+		{vd["odd"], 1, true},
+		{vd["odd"], int8(1), false},
+		{vd["odd"], "1", false},
+		{vd["short"], "", true},
+		{vd["short"], []byte{}, true},
+		{vd["short"], 1, false},
+		{vd["positive"], 1, true},
+		{vd["positive"], "", false},
+		{vd["legacy"], "", true},
+	} {
+		if ok := Accepts(c.fn, reflect.TypeOf(c.typ)); ok != c.ok {
+			t.Fatalf("wrong answer for %T: expected %v", c.typ, c.ok)
+		}
+	}
+
+	/* Typed validators still validate values as before */
+	if e := vd["positive"](-1); e != nil {
+		t.Fatal("unexpected error:", e)
+	}
+	if e := vd["odd"](2); e != "even" {
+		t.Fatal("wrong error:", e)
+	}
+	if e := vd["short"](1); e == nil {
+		t.Fatal("expected a type error")
+	}
+}
@@ -31,11 +31,46 @@ and should return an error when the value is deemed invalid.
 There is a reserved tag, "struct", which can be used to automatically validate a
 struct field, either named or embedded. This may be combined with user-defined validators.
 
+Validators registered through Param take a parameter from the tag, written either as
+"name=param" or "name(param)", e.g. `validate:"min=1,max(100)"`. A tag entry that matches
+a name in the map exactly is never split, so names such as "strlimit-1-20" keep working.
+
+A validator may also normalize a field rather than check it, by returning the new value
+wrapped with Modified (or by being built with Modifier). The remaining validators in the
+tag see the new value, and when Validate is given a pointer the value is written back to
+the field, so normalization and validation can be declared side by side:
+
+	type X struct {
+		Email string `validate:"trim,lower,email"`
+	}
+
+The reserved option "default=", also written "default(…)", fills a zero-valued field
+before any validator runs, for example `validate:"default=20,nonnegative"`. Strings,
+bools, all numeric kinds, time.Duration, time.Time (RFC 3339 or 2006-01-02) and slices
+of those are supported; slice elements are separated with '|'. As with modifiers, the
+field is only written to when Validate is given a pointer. Nested struct fields are
+filled as they are validated.
+
+Validators that need the request context, or the surroundings of the field, are written
+as a ValidatorCtxFn and registered through WithContext. ValidateContext passes its context
+to them, along with a FieldContext holding the path of the field, its parent struct and
+the top-level value, and stops early once the context is done.
+
+Mistakes in tags, such as undefined validators, invalid parameters or validators built
+with Rule, Strings or Typed used on fields of another type, are reported as failures of
+the fields when they are validated. V.CheckType reports them for a struct type beforehand.
+
+Validators may report failures with any value. The ones in package validators, and the
+engine itself, use Error, which carries a stable code and params alongside the message;
+Flatten turns a result into a flat map of field paths to Errors.
+
 Reflection is used to access the tags and fields, so the usual caveats and limitations apply.
 */
 package validate
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -61,27 +96,53 @@ type V map[string]ValidatorFn
 // Fields that are not tagged or cannot be interfaced via reflection
 // are skipped.
 func (v V) Validate(s interface{}) map[string]interface{} {
+	errors, _ := v.ValidateContext(context.Background(), s)
+	return errors
+}
+
+// ValidateContext is like Validate, but passes ctx to the validators created
+// with WithContext. If ctx is done before all fields have been validated,
+// ValidateContext stops and returns the errors found so far along with
+// ctx.Err(). A field whose validator was interrupted by ctx is not reported.
+func (v V) ValidateContext(ctx context.Context, s interface{}) (map[string]interface{}, error) {
+	w := walker{ctx: ctx, top: s}
 	errors := make(map[string]interface{})
-	v.validate(errors, s)
+	err := v.validate(&w, errors, reflect.ValueOf(s), "")
+	if err == nil {
+		err = ctx.Err()
+	}
 	if len(errors) > 0 {
-		return errors
+		return errors, err
 	}
-	return nil
+	return nil, err
 }
 
-func (v V) validate(errs map[string]interface{}, s interface{}) {
-	val := reflect.ValueOf(s)
+// walker holds the state shared by all the levels of a single validation.
+type walker struct {
+	ctx context.Context
+	top interface{}
+}
 
+func (v V) validate(w *walker, errs map[string]interface{}, val reflect.Value, path string) error {
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
 
+	if !val.IsValid() || val.Kind() != reflect.Struct {
+		return nil
+	}
 	t := val.Type()
-	if t == nil || t.Kind() != reflect.Struct {
-		return
+
+	parent := val.Interface()
+	if val.CanAddr() {
+		parent = val.Addr().Interface()
 	}
 
 	for i := 0; i < t.NumField(); i++ {
+		if err := w.ctx.Err(); err != nil {
+			return err
+		}
+
 		f := t.Field(i)
 		fv := val.Field(i)
 		if !fv.CanInterface() {
@@ -89,9 +150,28 @@ func (v V) validate(errs map[string]interface{}, s interface{}) {
 		}
 
 		val := fv.Interface()
-		fieldName := f.Name
-		if jsonTag := f.Tag.Get("json"); jsonTag != "" {
-			fieldName = strings.SplitN(jsonTag, ",", 2)[0]
+		fieldName := tagName(f)
+		fieldPath := fieldName
+		if path != "" {
+			fieldPath = path + "." + fieldName
+		}
+
+		var vts []string
+		if tag := f.Tag.Get("validate"); tag != "" {
+			vts = strings.Split(tag, ",")
+		}
+
+		vts, def, hasDefault := splitDefault(vts)
+		if hasDefault && fv.IsZero() {
+			dv, err := parseDefault(fv.Type(), def)
+			if err != nil {
+				errs[fieldName] = defaultError(def, err)
+				continue
+			}
+			if fv.CanSet() {
+				fv.Set(dv)
+			}
+			val = dv.Interface()
 		}
 
 		if validator, ok := val.(ValueValidator); ok {
@@ -101,36 +181,172 @@ func (v V) validate(errs map[string]interface{}, s interface{}) {
 			continue
 		}
 
+		mapped := false
 		if vmapper, ok := val.(ValueMapper); ok {
 			val = vmapper.MapValue()
+			mapped = true
 		}
 
-		tag := f.Tag.Get("validate")
-		if tag == "" {
+		if len(vts) == 0 {
 			continue
 		}
-		vts := strings.Split(tag, ",")
 
-		for _, vt := range vts {
-			if vt == "struct" {
-				errs2 := v.Validate(val)
-				if errs2 != nil {
-					/* A field validation has failed */
-					errs[fieldName] = errs2
-					break
-				}
-				continue
-			}
+		fs := field{
+			fv:     fv,
+			val:    val,
+			mapped: mapped,
+			name:   fieldName,
+			path:   fieldPath,
+			parent: parent,
+		}
+		e, err := v.check(w, &fs, vts)
+		if e != nil {
+			errs[fieldName] = e
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-			vf := v[vt]
-			if vf == nil {
-				errs[fieldName] = fmt.Errorf("undefined validator: %q", vt)
-				break
+// tagName returns the name of f in results: its JSON name, if any.
+func tagName(f reflect.StructField) string {
+	if jsonTag := f.Tag.Get("json"); jsonTag != "" {
+		return strings.SplitN(jsonTag, ",", 2)[0]
+	}
+	return f.Name
+}
+
+// Var validates a single value against the entries of tag, as if it were a
+// field tagged with it, and returns the failure or nil. Modifiers and
+// defaults take effect only for the rest of the tag; a nil val has no type
+// to parse a default as.
+func (v V) Var(tag string, val interface{}) interface{} {
+	vts, def, hasDefault := splitDefault(strings.Split(tag, ","))
+	if rv := reflect.ValueOf(val); hasDefault && (!rv.IsValid() || rv.IsZero()) {
+		if !rv.IsValid() {
+			return defaultError(def, errors.New("no type for a nil value"))
+		}
+		dv, err := parseDefault(rv.Type(), def)
+		if err != nil {
+			return defaultError(def, err)
+		}
+		val = dv.Interface()
+	}
+
+	w := walker{ctx: context.Background(), top: val}
+	f := field{val: val}
+	e, _ := v.check(&w, &f, vts)
+	return e
+}
+
+// field is a value being checked against the entries of its tag.
+type field struct {
+	// fv is the field itself; it is invalid when there is no field to write to.
+	fv     reflect.Value
+	val    interface{}
+	mapped bool
+	name   string
+	path   string
+	parent interface{}
+}
+
+// check runs the validators named in vts on f, in order, and returns the
+// first failure. The error is only set if the context is done.
+func (v V) check(w *walker, f *field, vts []string) (interface{}, error) {
+	for _, vt := range vts {
+		if vt == "struct" {
+			/* Validate in place, so modifiers can reach nested fields */
+			nested := reflect.ValueOf(f.val)
+			if !f.mapped && f.fv.Kind() != reflect.Ptr && f.fv.CanAddr() {
+				nested = f.fv.Addr()
+			}
+			errs := make(map[string]interface{})
+			err := v.validate(w, errs, nested, f.path)
+			if len(errs) > 0 {
+				/* A field validation has failed */
+				return errs, err
 			}
-			if err := vf(val); err != nil {
-				errs[fieldName] = err
-				break
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		e, err := v.run(w, f, vt)
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := e.(modified); ok {
+			if !f.mapped && f.fv.CanSet() {
+				if err := setValue(f.fv, m.val); err != nil {
+					return err, nil
+				}
 			}
+			f.val = m.val
+			continue
+		}
+		if e != nil {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+// lookup finds the validator named by the tag entry vt: the entry itself,
+// or the name before its parameter.
+func (v V) lookup(vt string) (vf ValidatorFn, name, param string, hasParam bool) {
+	if vf = v[vt]; vf != nil {
+		return vf, vt, "", false
+	}
+	if name, param, hasParam = splitParam(vt); hasParam {
+		vf = v[name]
+	}
+	return vf, name, param, hasParam
+}
+
+// run calls the validator named by the tag entry vt and completes the
+// pending calls of parameterized and context-aware validators. The error is
+// only set if the context is done once a context-aware validator returns, in
+// which case its result is dropped.
+func (v V) run(w *walker, f *field, vt string) (interface{}, error) {
+	vf, name, param, hasParam := v.lookup(vt)
+	if vf == nil {
+		return NewError("validator.undefined",
+			fmt.Sprintf("undefined validator: %q", vt), Params{"name": vt}), nil
+	}
+
+	e := vf(f.val)
+	if p, ok := e.(paramCall); ok {
+		fn, err := p.build(param)
+		if err != nil {
+			return NewError("validator.params",
+				fmt.Sprintf("invalid parameters for %q: %v", name, err), Params{"name": name}), nil
+		}
+		e = fn(f.val)
+	} else if hasParam {
+		return NewError("validator.params",
+			fmt.Sprintf("validator %q does not take parameters", name), Params{"name": name}), nil
+	}
+
+	if c, ok := e.(ctxCall); ok {
+		e = nil
+		fc := FieldContext{
+			Value:  c.val,
+			Name:   f.name,
+			Path:   f.path,
+			Parent: f.parent,
+			Top:    w.top,
+		}
+		err := c.fn(w.ctx, fc)
+		if ctxErr := w.ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if err != nil {
+			e = err
 		}
 	}
+	return e, nil
 }
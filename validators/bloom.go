@@ -0,0 +1,132 @@
+package validators
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"io"
+	"math"
+	"strings"
+)
+
+// BloomFilter is a compact set of strings that answers membership queries
+// with no false negatives and a configurable rate of false positives. It
+// holds lists of common or breached passwords for PasswordPolicy without
+// keeping the passwords themselves.
+//
+// A filter is built once, e.g. by a tool run over a password list, saved
+// with MarshalBinary and loaded with UnmarshalBinary. Adding to a filter
+// is not safe for concurrent use; querying is.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 /* number of bits */
+	k    uint32 /* number of hashes */
+}
+
+const bloomMagic = "BLM1"
+
+// NewBloomFilter returns an empty filter sized for n strings and a false
+// positive rate of fpRate, e.g. 0.001.
+func NewBloomFilter(n int, fpRate float64) *BloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.001
+	}
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	if k < 1 {
+		k = 1
+	}
+	return newBloomFilter(uint64(m), uint32(k))
+}
+
+func newBloomFilter(m uint64, k uint32) *BloomFilter {
+	return &BloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// hashes returns the base hashes of s; the k indices are derived from them
+// by double hashing.
+func (f *BloomFilter) hashes(s string) (uint64, uint64) {
+	h := fnv.New128a()
+	io.WriteString(h, s)
+	sum := h.Sum(nil)
+	return binary.LittleEndian.Uint64(sum[:8]), binary.LittleEndian.Uint64(sum[8:]) | 1
+}
+
+// Add adds s to the filter.
+func (f *BloomFilter) Add(s string) {
+	h1, h2 := f.hashes(s)
+	for i := uint64(0); i < uint64(f.k); i++ {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Contains reports whether s may have been added to the filter.
+func (f *BloomFilter) Contains(s string) bool {
+	if f == nil || f.m == 0 {
+		return false
+	}
+	h1, h2 := f.hashes(s)
+	for i := uint64(0); i < uint64(f.k); i++ {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AddLines adds every nonempty line read from r, with surrounding spaces
+// removed, and returns the number of lines added.
+func (f *BloomFilter) AddLines(r io.Reader) (int, error) {
+	n := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			f.Add(line)
+			n++
+		}
+	}
+	return n, sc.Err()
+}
+
+// MarshalBinary encodes the filter.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(bloomMagic)+12+8*len(f.bits))
+	data = append(data, bloomMagic...)
+	data = binary.LittleEndian.AppendUint32(data, f.k)
+	data = binary.LittleEndian.AppendUint64(data, f.m)
+	for _, w := range f.bits {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary.
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	hdr := len(bloomMagic) + 12
+	if len(data) < hdr || string(data[:len(bloomMagic)]) != bloomMagic {
+		return errors.New("validators: invalid bloom filter data")
+	}
+	k := binary.LittleEndian.Uint32(data[len(bloomMagic):])
+	m := binary.LittleEndian.Uint64(data[len(bloomMagic)+4:])
+	/* Bound m by the data first, so that rounding it up cannot overflow */
+	words := uint64(len(data)-hdr) / 8
+	if k == 0 || m == 0 || m > 64*words || uint64(len(data)-hdr) != 8*((m+63)/64) {
+		return errors.New("validators: invalid bloom filter data")
+	}
+
+	*f = *newBloomFilter(m, k)
+	for i := range f.bits {
+		f.bits[i] = binary.LittleEndian.Uint64(data[hdr+8*i:])
+	}
+	return nil
+}
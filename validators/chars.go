@@ -0,0 +1,136 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// charClass is a set of characters a string must consist of.
+type charClass struct {
+	err validate.Error
+	ok  func(r rune) bool
+}
+
+func isLetter(r rune) bool {
+	/* Combining marks are part of letters in decomposed text */
+	return unicode.IsLetter(r) || unicode.IsMark(r)
+}
+
+func isLineBreak(r rune) bool {
+	switch r {
+	case '\n', '\v', '\f', '\r', '\u0085', '\u2028', '\u2029':
+		return true
+	}
+	return false
+}
+
+var charClasses = map[string]charClass{
+	"alpha": {
+		validate.NewError("string.alpha", "Should contain only letters", nil),
+		isLetter,
+	},
+	"alnum": {
+		validate.NewError("string.alnum", "Should contain only letters and digits", nil),
+		func(r rune) bool { return isLetter(r) || unicode.IsDigit(r) },
+	},
+	"numeric": {
+		validate.NewError("string.numeric", "Should contain only digits", nil),
+		unicode.IsDigit,
+	},
+	"ascii": {
+		validate.NewError("string.ascii", "Should contain only ASCII characters", nil),
+		func(r rune) bool { return r < utf8.RuneSelf },
+	},
+	"printable": {
+		validate.NewError("string.printable", "Should contain only printable characters", nil),
+		unicode.IsPrint,
+	},
+	"nocontrol": {
+		validate.NewError("string.control", "Should not contain control characters", nil),
+		func(r rune) bool { return !unicode.IsControl(r) },
+	},
+	"singleline": {
+		validate.NewError("string.multiline", "Should be a single line", nil),
+		func(r rune) bool { return !isLineBreak(r) },
+	},
+	"lowercase": {
+		validate.NewError("string.lowercase", "Should be lowercase", nil),
+		func(r rune) bool { return !unicode.IsUpper(r) && !unicode.IsTitle(r) },
+	},
+	"uppercase": {
+		validate.NewError("string.uppercase", "Should be uppercase", nil),
+		func(r rune) bool { return !unicode.IsLower(r) && !unicode.IsTitle(r) },
+	},
+}
+
+// charsValidator returns a constructor of validators for the class name
+// that takes an optional '|'-separated list of Unicode scripts as its
+// parameter, e.g. "Latin|Cyrillic".
+func charsValidator(name string) func(param string) (validate.ValidatorFn, error) {
+	return func(param string) (validate.ValidatorFn, error) {
+		var scripts []string
+		if param != "" {
+			scripts = strings.Split(param, "|")
+		}
+		return chars(name, scripts)
+	}
+}
+
+func chars(name string, scripts []string) (validate.ValidatorFn, error) {
+	class, ok := charClasses[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown character class %q", name)
+	}
+
+	tables := make([]*unicode.RangeTable, len(scripts))
+	for i := range scripts {
+		if tables[i] = unicode.Scripts[scripts[i]]; tables[i] == nil {
+			return nil, fmt.Errorf("unknown script %q", scripts[i])
+		}
+	}
+	scriptErr := validate.NewError("string.script",
+		"Should only contain letters of: "+strings.Join(scripts, ", "),
+		validate.Params{"scripts": scripts})
+
+	return validate.Strings(func(str string) interface{} {
+		if !utf8.ValidString(str) {
+			return class.err
+		}
+		for _, r := range str {
+			if !class.ok(r) {
+				return class.err
+			}
+			if len(tables) > 0 && unicode.IsLetter(r) && !unicode.IsOneOf(tables, r) {
+				return scriptErr
+			}
+		}
+		return nil
+	}), nil
+}
+
+// Chars returns a validator that checks that strings, byte arrays and each
+// element of string arrays consist only of characters of the named class:
+//
+//	alpha       letters (with their combining marks)
+//	alnum       letters and decimal digits
+//	numeric     decimal digits of any script
+//	ascii       ASCII characters
+//	printable   characters accepted by unicode.IsPrint
+//	nocontrol   anything but control characters
+//	singleline  anything but line breaks
+//	lowercase   no upper or title case letters
+//	uppercase   no lower or title case letters
+//
+// If scripts are given (by their names in unicode.Scripts, e.g. "Latin"),
+// letters must also belong to one of them. Invalid UTF-8 never passes.
+// The same validators are registered in V under the names of the classes,
+// where scripts are given as a '|'-separated parameter: `alpha=Latin|Greek`.
+//
+// Chars panics if the class or a script is unknown.
+func Chars(class string, scripts ...string) validate.ValidatorFn {
+	return must(chars(class, scripts))
+}
@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestCharClasses(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, c := range []struct {
+		class string
+		ok    []string
+		bad   []string
+	}{
+		{"alpha", []string{"", "abc", "Ünïcödé", "é", "日本語", "Ελληνικά"}, []string{"ab1", "a b", "a-b", "\xff"}},
+		{"alnum", []string{"abc123", "Straße2", "٣٤٥"}, []string{"a_1", "a 1", "½"}},
+		{"numeric", []string{"0123", "٠١٢", "０９"}, []string{"1.5", "-1", "1e3", "½"}},
+		{"ascii", []string{"Hello, world!\n", "\x00"}, []string{"héllo", "\xff"}},
+		{"printable", []string{"Hello, world!", "日本語 ok"}, []string{"a\tb", "a\nb", "\u200b"}},
+		{"nocontrol", []string{"a\u200bb", "tab-less"}, []string{"a\tb", "\x00", "\u0085"}},
+		{"singleline", []string{"a\tb c"}, []string{"a\nb", "a\rb", "a\u2028b"}},
+		{"lowercase", []string{"abc 1", "ß", "日本"}, []string{"aBc", "ǅ", "É"}},
+		{"uppercase", []string{"ABC 1", "É", "日本"}, []string{"AbC", "ǅ", "ß"}},
+	} {
+		v := Chars(c.class)
+		for _, s := range c.ok {
+			Ω(v(s)).Should(BeNil(), "%s(%q)", c.class, s)
+		}
+		for _, s := range c.bad {
+			Ω(v(s)).Should(Equal(charClasses[c.class].err), "%s(%q)", c.class, s)
+		}
+	}
+}
+
+func TestCharClassesTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	alpha := Chars("alpha")
+	alphaErr := charClasses["alpha"].err
+
+	Ω(alpha([]byte("abc"))).Should(BeNil())
+	Ω(alpha([]byte("ab1"))).Should(Equal(alphaErr))
+	Ω(alpha([]string{"a", "b"})).Should(BeNil())
+	Ω(alpha([]string{"a", "1", "b"})).Should(Equal([]interface{}{nil, alphaErr, nil}))
+	Ω(alpha(1)).Should(Equal(validate.NewError("string.type", "Should be a string or byte array", nil)))
+}
+
+func TestCharClassesScripts(t *testing.T) {
+	RegisterTestingT(t)
+
+	latin := Chars("alnum", "Latin")
+	scriptErr := validate.NewError("string.script", "Should only contain letters of: Latin",
+		validate.Params{"scripts": []string{"Latin"}})
+
+	Ω(latin("Ünïcödé123")).Should(BeNil())
+	Ω(latin("Ελληνικά")).Should(Equal(scriptErr))
+	Ω(latin("аbc")).Should(Equal(scriptErr)) /* Cyrillic 'а' */
+
+	type X struct {
+		Name string   `validate:"alpha=Latin|Greek"`
+		Tags []string `validate:"lowercase,singleline"`
+	}
+	Ω(V.Validate(X{Name: "Ελληνικάabc", Tags: []string{"a", "b"}})).Should(BeNil())
+
+	errs := V.Validate(X{Name: "日本", Tags: []string{"a", "B"}})
+	Ω(errs).Should(HaveLen(2))
+	Ω(errs["Name"]).Should(MatchError("Should only contain letters of: Latin, Greek"))
+	Ω(errs["Tags"]).Should(Equal([]interface{}{nil, charClasses["lowercase"].err}))
+
+	Ω(V.Var("alpha=Klingon", "a").(validate.Error).Code).Should(Equal("validator.params"))
+	Ω(func() { Chars("alpha", "Klingon") }).Should(Panic())
+	Ω(func() { Chars("vowels") }).Should(Panic())
+}
@@ -0,0 +1,275 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PlanitarInc/validate"
+	"golang.org/x/net/idna"
+)
+
+// DomainResolver looks up the mail exchangers and addresses of domains.
+// *net.Resolver implements it, so net.DefaultResolver can be used as is,
+// while tests can use a stub.
+type DomainResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailOptions is the policy checked by Email. The zero value accepts
+// ASCII addresses of the form local@domain.tld, where the local part is a
+// dot-atom as defined by RFC 5322 and the domain is a host name, possibly
+// internationalized.
+type EmailOptions struct {
+	// AllowQuoted accepts quoted local parts: "john smith"@example.com.
+	AllowQuoted bool
+	// SMTPUTF8 accepts non-ASCII local parts as defined by RFC 6531.
+	// Internationalized domains are accepted regardless, since they can be
+	// reached through their ASCII form.
+	SMTPUTF8 bool
+	// AllowDomainLiteral accepts address literals instead of domains:
+	// user@[192.0.2.1] or user@[IPv6:2001:db8::1].
+	AllowDomainLiteral bool
+	// AllowSingleLabel accepts domains of a single label, e.g. user@localhost.
+	AllowSingleLabel bool
+	// Resolver, if set, is used to check that the domain accepts mail: it
+	// has MX records other than a null MX (RFC 7505), or else an address.
+	// The lookup honors the context passed to ValidateContext.
+	Resolver DomainResolver
+}
+
+const (
+	maxEmailLen  = 254 /* RFC 5321 path limit, without the angle brackets */
+	maxLocalLen  = 64
+	emailSpecial = "!#$%&'*+-/=?^_`{|}~"
+)
+
+var (
+	emailTooLongErr = validate.NewError("email.too_long",
+		fmt.Sprintf("Maximum length is %d", maxEmailLen), validate.Params{"max": maxEmailLen})
+	emailLookupErr = validate.NewError("email.lookup", "Could not verify the email domain", nil)
+)
+
+/* IDNA processing for lookup, reporting the labels the DNS cannot hold */
+var emailIDNA = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.VerifyDNSLength(true),
+	idna.StrictDomainName(true),
+)
+
+func isAtext(r rune, intl bool) bool {
+	switch {
+	case r >= utf8.RuneSelf:
+		return intl && unicode.IsGraphic(r) && !unicode.IsSpace(r)
+	case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+		return true
+	}
+	return strings.ContainsRune(emailSpecial, r)
+}
+
+func isDotAtom(s string, intl bool) bool {
+	for _, atom := range strings.Split(s, ".") {
+		if atom == "" {
+			return false
+		}
+		for _, r := range atom {
+			if !isAtext(r, intl) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isQuoted reports whether s is a quoted string: printable characters and
+// spaces between double quotes, where quotes and backslashes are escaped
+// by a backslash.
+func isQuoted(s string, intl bool) bool {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return false
+	}
+	escaped := false
+	for _, r := range s[1 : len(s)-1] {
+		switch {
+		case r >= utf8.RuneSelf:
+			if !intl || !unicode.IsGraphic(r) {
+				return false
+			}
+		case r != ' ' && r != '\t' && (r < '!' || r > '~'):
+			return false
+		case !escaped && r == '"':
+			return false
+		case !escaped && r == '\\':
+			escaped = true
+			continue
+		}
+		escaped = false
+	}
+	return !escaped
+}
+
+// splitEmail checks the syntax of an address and returns its domain in
+// ASCII form, or an empty string for address literals.
+func splitEmail(str string, opts *EmailOptions) (domain string, e interface{}) {
+	if !utf8.ValidString(str) {
+		return "", emailErr
+	}
+	at := strings.LastIndexByte(str, '@')
+	if at < 0 {
+		return "", emailErr
+	}
+	local, domain := str[:at], str[at+1:]
+
+	switch {
+	case local == "" || len(local) > maxLocalLen:
+		return "", emailErr
+	case local[0] == '"':
+		if !opts.AllowQuoted || !isQuoted(local, opts.SMTPUTF8) {
+			return "", emailErr
+		}
+	case !isDotAtom(local, opts.SMTPUTF8):
+		return "", emailErr
+	}
+
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		if !opts.AllowDomainLiteral {
+			return "", emailErr
+		}
+		lit := domain[1 : len(domain)-1]
+		addr, ok := parseIP(strings.TrimPrefix(lit, "IPv6:"))
+		if !ok || addr.Is4() == strings.HasPrefix(lit, "IPv6:") {
+			return "", emailErr
+		}
+		if len(str) > maxEmailLen {
+			return "", emailTooLongErr
+		}
+		return "", nil
+	}
+
+	if strings.HasSuffix(domain, ".") {
+		return "", emailErr
+	}
+	ascii, err := emailIDNA.ToASCII(domain)
+	if err != nil || !isHostname(ascii) {
+		return "", emailErr
+	}
+	if !isFQDN(ascii) && !(opts.AllowSingleLabel && !strings.Contains(ascii, ".")) {
+		return "", emailErr
+	}
+	if len(local)+1+len(ascii) > maxEmailLen {
+		return "", emailTooLongErr
+	}
+	return ascii, nil
+}
+
+// acceptsMail reports whether domain has a usable MX record, or an address
+// to fall back to when it has no MX records at all (RFC 5321, 5.1).
+func acceptsMail(ctx context.Context, r DomainResolver, domain string) (bool, error) {
+	mxs, err := r.LookupMX(ctx, domain)
+	if err == nil && len(mxs) > 0 {
+		/* RFC 7505: a single MX of "." means the domain takes no mail */
+		return !(len(mxs) == 1 && strings.Trim(mxs[0].Host, ".") == ""), nil
+	}
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+
+	addrs, err := r.LookupHost(ctx, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(addrs) > 0, nil
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// Email returns a validator that checks that strings, byte arrays and each
+// element of string arrays are email addresses: addr-spec as defined by
+// RFC 5322 and RFC 6531, without comments and obsolete forms, whose
+// domain is valid under IDNA and which fit the length limits of RFC 5321.
+// What else is accepted is set by opts.
+//
+// V has the following email validators registered, where the parameter
+// may also be given as `email(strict)`:
+//
+//	email         dot-atom or quoted local parts, RFC 6531 included
+//	email=strict  ASCII dot-atom local parts only
+//	email=rfc     domain literals and single-label domains as well
+//
+// With a Resolver, the validator checks the domains of syntactically valid
+// addresses with it when run by Validate or ValidateContext; failures of
+// arrays report the first failing domain.
+func Email(opts EmailOptions) validate.ValidatorFn {
+	syntax := validate.Strings(func(str string) interface{} {
+		_, e := splitEmail(str, &opts)
+		return e
+	})
+	if opts.Resolver == nil {
+		return syntax
+	}
+
+	return validate.Typed(func(src interface{}) interface{} {
+		var domains []string
+		e := validate.Strings(func(str string) interface{} {
+			domain, e := splitEmail(str, &opts)
+			if domain != "" {
+				domains = append(domains, domain)
+			}
+			return e
+		})(src)
+		if e != nil || len(domains) == 0 {
+			return e
+		}
+
+		return validate.WithContext(func(ctx context.Context, _ validate.FieldContext) error {
+			checked := map[string]bool{}
+			for _, domain := range domains {
+				if checked[domain] {
+					continue
+				}
+				checked[domain] = true
+
+				ok, err := acceptsMail(ctx, opts.Resolver, domain)
+				if err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					return emailLookupErr
+				}
+				if !ok {
+					return validate.NewError("email.domain",
+						"Domain does not accept email: "+domain, validate.Params{"domain": domain})
+				}
+			}
+			return nil
+		})(src)
+	}, func(t reflect.Type) bool {
+		return validate.Accepts(syntax, t)
+	})
+}
+
+// emailOptions are the options of the email validators of V, by
+// parameter.
+var emailOptions = map[string]EmailOptions{
+	"":       {AllowQuoted: true, SMTPUTF8: true},
+	"strict": {},
+	"rfc": {
+		AllowQuoted:        true,
+		SMTPUTF8:           true,
+		AllowDomainLiteral: true,
+		AllowSingleLabel:   true,
+	},
+}
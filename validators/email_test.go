@@ -0,0 +1,160 @@
+package validators
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestEmail(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, c := range []struct {
+		opts EmailOptions
+		ok   []string
+		bad  []string
+	}{
+		{EmailOptions{},
+			[]string{"a@b.co", "first.last+tag@example.com", "o'brien@example.ie", "x@xn--bcher-kva.example", "x@B\u00dcCHER.example"},
+			[]string{"a..b@example.com", ".a@example.com", "a.@example.com", "\"q\"@example.com", "j\u00f6rg@example.com",
+				"a b@example.com", "a@localhost", "a@[10.0.0.1]", "a@example.123", "a@exa_mple.com", "a@-example.com",
+				"a@example.com.", "a@@example.com", "\xff@example.com"}},
+		{EmailOptions{AllowQuoted: true},
+			[]string{"\"john smith\"@example.com", "\"a\\\"b\\\\c\"@example.com", "\"@\"@example.com", "\"\"@example.com"},
+			[]string{"\"a\"b\"@example.com", "\"a\\\"@example.com", "\"a\nb\"@example.com", "\"j\u00f6rg\"@example.com"}},
+		{EmailOptions{SMTPUTF8: true},
+			[]string{"j\u00f6rg@example.com", "\u7528\u6237@\u4f8b\u5b50.\u5e7f\u544a"},
+			[]string{"a\u00a0b@example.com", "a\u200bb@example.com"}},
+		{EmailOptions{AllowDomainLiteral: true, AllowSingleLabel: true},
+			[]string{"a@[192.0.2.1]", "a@[IPv6:2001:db8::1]", "root@localhost"},
+			[]string{"a@[IPv6:192.0.2.1]", "a@[2001:db8::1]", "a@[300.0.0.1]", "a@[example.com]"}},
+	} {
+		v := Email(c.opts)
+		for _, s := range c.ok {
+			Ω(v(s)).Should(BeNil(), "%+v %q", c.opts, s)
+		}
+		for _, s := range c.bad {
+			Ω(v(s)).Should(Equal(emailErr), "%+v %q", c.opts, s)
+		}
+	}
+
+	v := Email(EmailOptions{})
+	Ω(v(strings.Repeat("a", 64) + "@example.com")).Should(BeNil())
+	Ω(v(strings.Repeat("a", 65) + "@example.com")).Should(Equal(emailErr))
+	long := strings.Repeat("a", 61) + "@" + strings.Repeat("b.", 95) + "com"
+	Ω(v(long)).Should(Equal(validate.NewError("email.too_long", "Maximum length is 254",
+		validate.Params{"max": 254})))
+	Ω(v([]string{"a@b.co", "nope"})).Should(Equal([]interface{}{nil, emailErr}))
+	Ω(v(1)).Should(Equal(validate.NewError("string.type", "Should be a string or byte array", nil)))
+}
+
+func TestEmailTags(t *testing.T) {
+	RegisterTestingT(t)
+
+	type X struct {
+		Contact string `validate:"email"`
+		Billing string `validate:"email=strict"`
+		Admin   string `validate:"email=rfc"`
+	}
+	Ω(V.Validate(X{"\"a b\"@example.com", "billing@example.com", "root@localhost"})).Should(BeNil())
+	Ω(V.Validate(X{"a@b", "\"a b\"@example.com", "a@[::1]"})).Should(Equal(map[string]interface{}{
+		"Contact": emailErr,
+		"Billing": emailErr,
+		"Admin":   emailErr,
+	}))
+
+	Ω(V.Var("email(strict)", "\"a b\"@example.com")).Should(Equal(emailErr))
+	Ω(V.Var("email(rfc)", "root@localhost")).Should(BeNil())
+	Ω(V["email(strict)"]("a@example.com")).Should(BeNil())
+	Ω(V.Var("email=loose", "a@example.com")).Should(Equal(validate.NewError("validator.params",
+		`validator "email" does not take parameters`, validate.Params{"name": "email"})))
+}
+
+type stubResolver struct {
+	mx      map[string][]*net.MX
+	hosts   map[string][]string
+	lookups []string
+	// cancel is called on looking up cancel.example.
+	cancel func()
+}
+
+func (r *stubResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.lookups = append(r.lookups, "mx:"+name)
+	if name == "timeout.example" {
+		return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+	}
+	if name == "cancel.example" && r.cancel != nil {
+		r.cancel()
+		return nil, ctx.Err()
+	}
+	if mx, ok := r.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *stubResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups = append(r.lookups, "host:"+host)
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestEmailResolver(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := &stubResolver{
+		mx: map[string][]*net.MX{
+			"example.com":           {{Host: "mx.example.com.", Pref: 10}},
+			"xn--bcher-kva.example": {{Host: "mx.example.com.", Pref: 10}},
+			"nomail.example":        {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{
+			"a-only.example": {"192.0.2.1"},
+		},
+	}
+	vd := validate.V{"email": Email(EmailOptions{Resolver: r})}
+
+	type X struct {
+		Email string   `validate:"email"`
+		CC    []string `validate:"email"`
+	}
+
+	Ω(vd.Validate(X{"a@example.com", []string{"b@b\u00fccher.example", "c@a-only.example", "d@example.com"}})).Should(BeNil())
+	Ω(r.lookups).Should(Equal([]string{
+		"mx:example.com",
+		"mx:xn--bcher-kva.example",
+		"mx:a-only.example", "host:a-only.example",
+		"mx:example.com", /* domains are looked up once per field */
+	}))
+
+	errs := vd.Validate(X{"a@nomail.example", []string{"b@example.com", "c@missing.example"}})
+	Ω(errs).Should(Equal(map[string]interface{}{
+		"Email": validate.NewError("email.domain", "Domain does not accept email: nomail.example",
+			validate.Params{"domain": "nomail.example"}),
+		"CC": validate.NewError("email.domain", "Domain does not accept email: missing.example",
+			validate.Params{"domain": "missing.example"}),
+	}))
+
+	errs = vd.Validate(X{"a@timeout.example", []string{"nope"}})
+	Ω(errs).Should(Equal(map[string]interface{}{
+		"Email": emailLookupErr,
+		"CC":    []interface{}{emailErr},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := vd.ValidateContext(ctx, X{Email: "a@example.com"})
+	Ω(err).Should(MatchError(context.Canceled))
+
+	/* A lookup interrupted by the cancellation is not a failure */
+	ctx, r.cancel = context.WithCancel(context.Background())
+	errs, err = vd.ValidateContext(ctx, X{Email: "a@example.com", CC: []string{"b@cancel.example"}})
+	Ω(err).Should(MatchError(context.Canceled))
+	Ω(errs).Should(BeNil())
+}
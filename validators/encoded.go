@@ -0,0 +1,193 @@
+package validators
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// splitBounds splits a "min..max" parameter, where either bound may be
+// left out; a single value is both bounds.
+func splitBounds(param string) (lo, hi string) {
+	lo, hi, isRange := strings.Cut(param, "..")
+	if !isRange {
+		hi = lo
+	}
+	return strings.TrimSpace(lo), strings.TrimSpace(hi)
+}
+
+// datetimeValidator takes a layout of package time as parameter:
+// `datetime=2006-01-02 15:04`. Layouts with commas cannot be used in tags.
+func datetimeValidator(layout string) (validate.ValidatorFn, error) {
+	if layout == "" {
+		return nil, errors.New("expected a layout, e.g. 2006-01-02")
+	}
+	e := validate.NewError("datetime.invalid", "Should be a time in the format "+layout,
+		validate.Params{"layout": layout})
+	return validate.Strings(func(str string) interface{} {
+		if _, err := time.Parse(layout, str); err != nil {
+			return e
+		}
+		return nil
+	}), nil
+}
+
+// durationValidator, intValidator and floatValidator take optional bounds
+// of the parsed value as "min..max": `duration=1s..1h`, `int=0..` or
+// `float=..1`.
+func durationValidator(param string) (validate.ValidatorFn, error) {
+	lo, hi := splitBounds(param)
+	min, max := time.Duration(math.MinInt64), time.Duration(math.MaxInt64)
+	for _, b := range []struct {
+		text string
+		d    *time.Duration
+	}{{lo, &min}, {hi, &max}} {
+		if b.text == "" {
+			continue
+		}
+		d, err := time.ParseDuration(b.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q", b.text)
+		}
+		*b.d = d
+	}
+	if min > max {
+		return nil, fmt.Errorf("empty range %s", param)
+	}
+
+	return validate.Strings(func(str string) interface{} {
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return validate.NewError("duration.invalid", "Should be a duration, e.g. 1h30m", nil)
+		}
+		if d < min {
+			return validate.NewError("duration.too_short", "Minimum duration is "+min.String(),
+				validate.Params{"min": min})
+		}
+		if d > max {
+			return validate.NewError("duration.too_long", "Maximum duration is "+max.String(),
+				validate.Params{"max": max})
+		}
+		return nil
+	}), nil
+}
+
+func intValidator(param string) (validate.ValidatorFn, error) {
+	lo, hi := splitBounds(param)
+	min, max := int64(math.MinInt64), int64(math.MaxInt64)
+	var err error
+	if lo != "" {
+		if min, err = strconv.ParseInt(lo, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid integer %q", lo)
+		}
+	}
+	if hi != "" {
+		if max, err = strconv.ParseInt(hi, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid integer %q", hi)
+		}
+	}
+	if min > max {
+		return nil, fmt.Errorf("empty range %s", param)
+	}
+
+	return validate.Strings(func(str string) interface{} {
+		n, err := strconv.ParseInt(str, 10, 64)
+		switch {
+		case err != nil:
+			return validate.NewError("int.invalid", "Should be an integer", nil)
+		case n < min:
+			return minErr(lo)
+		case n > max:
+			return maxErr(hi)
+		}
+		return nil
+	}), nil
+}
+
+func floatValidator(param string) (validate.ValidatorFn, error) {
+	lo, hi := splitBounds(param)
+	min, max := math.Inf(-1), math.Inf(1)
+	var err error
+	if lo != "" {
+		if min, err = strconv.ParseFloat(lo, 64); err != nil || math.IsNaN(min) {
+			return nil, fmt.Errorf("invalid number %q", lo)
+		}
+	}
+	if hi != "" {
+		if max, err = strconv.ParseFloat(hi, 64); err != nil || math.IsNaN(max) {
+			return nil, fmt.Errorf("invalid number %q", hi)
+		}
+	}
+	if min > max {
+		return nil, fmt.Errorf("empty range %s", param)
+	}
+
+	return validate.Strings(func(str string) interface{} {
+		f, err := strconv.ParseFloat(str, 64)
+		switch {
+		case err != nil || math.IsNaN(f) || math.IsInf(f, 0):
+			return validate.NewError("float.invalid", "Should be a number", nil)
+		case f < min:
+			return minErr(lo)
+		case f > max:
+			return maxErr(hi)
+		}
+		return nil
+	}), nil
+}
+
+// decodes returns a validator that checks that strings decode with one of
+// the functions.
+func decodes(e validate.Error, decode ...func(string) error) validate.ValidatorFn {
+	return validate.Strings(func(str string) interface{} {
+		for _, fn := range decode {
+			if fn(str) == nil {
+				return nil
+			}
+		}
+		return e
+	})
+}
+
+func decodeWith(enc *base64.Encoding) func(string) error {
+	return func(s string) error {
+		_, err := enc.Strict().DecodeString(s)
+		return err
+	}
+}
+
+var (
+	boolValidator = decodes(validate.NewError("bool.invalid", "Should be true or false", nil),
+		func(s string) error {
+			_, err := strconv.ParseBool(s)
+			return err
+		})
+
+	jsonValidator = decodes(validate.NewError("json.invalid", "Should be valid JSON", nil),
+		func(s string) error {
+			if !json.Valid([]byte(s)) {
+				return errors.New("invalid JSON")
+			}
+			return nil
+		})
+
+	/* Padding is required in standard base64 and optional in base64url */
+	base64Validator = decodes(validate.NewError("base64.invalid", "Should be base64 encoded", nil),
+		decodeWith(base64.StdEncoding))
+	base64urlValidator = decodes(validate.NewError("base64url.invalid", "Should be base64url encoded", nil),
+		decodeWith(base64.URLEncoding), decodeWith(base64.RawURLEncoding))
+
+	hexValidator = decodes(validate.NewError("hex.invalid", "Should be hex encoded", nil),
+		func(s string) error {
+			_, err := hex.DecodeString(s)
+			return err
+		})
+)
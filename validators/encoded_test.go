@@ -0,0 +1,97 @@
+package validators
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestParsedNumbers(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(V.Var("int", "-42")).Should(BeNil())
+	Ω(V.Var("int", "9223372036854775807")).Should(BeNil())
+	for _, s := range []string{"", "1.0", "1e3", "0x10", " 1", "9223372036854775808"} {
+		Ω(V.Var("int", s)).Should(Equal(validate.NewError("int.invalid", "Should be an integer", nil)), s)
+	}
+	Ω(V.Var("int=1..10", "10")).Should(BeNil())
+	Ω(V.Var("int=1..10", "0")).Should(Equal(validate.NewError("number.too_small", "Minimum value is 1",
+		validate.Params{"min": json.Number("1")})))
+	Ω(V.Var("int=..10", "11")).Should(Equal(validate.NewError("number.too_large", "Maximum value is 10",
+		validate.Params{"max": json.Number("10")})))
+	Ω(V.Var("int=0..", []string{"0", "-1"})).Should(HaveLen(2))
+
+	Ω(V.Var("float", "1.5e3")).Should(BeNil())
+	Ω(V.Var("float", "-.5")).Should(BeNil())
+	for _, s := range []string{"", "NaN", "Inf", "1,5", "abc"} {
+		Ω(V.Var("float", s)).Should(Equal(validate.NewError("float.invalid", "Should be a number", nil)), s)
+	}
+	Ω(V.Var("float=0..1", "0.5")).Should(BeNil())
+	Ω(V.Var("float=0..1", "1.01").(validate.Error).Code).Should(Equal("number.too_large"))
+
+	for _, tag := range []string{"int=a..b", "int=5..1", "float=x", "float=2..1", "duration=1x", "duration=1h..1s", "datetime"} {
+		Ω(V.Var(tag, "1").(validate.Error).Code).Should(Equal("validator.params"), tag)
+	}
+}
+
+func TestParsedTimes(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(V.Var("duration", "1h30m")).Should(BeNil())
+	Ω(V.Var("duration", "-5s")).Should(BeNil())
+	Ω(V.Var("duration", "5")).Should(Equal(validate.NewError("duration.invalid",
+		"Should be a duration, e.g. 1h30m", nil)))
+	Ω(V.Var("duration=1s..1h", "59m")).Should(BeNil())
+	Ω(V.Var("duration=1s..1h", "500ms")).Should(Equal(validate.NewError("duration.too_short",
+		"Minimum duration is 1s", validate.Params{"min": time.Second})))
+	Ω(V.Var("duration=1s..1h", "2h")).Should(Equal(validate.NewError("duration.too_long",
+		"Maximum duration is 1h0m0s", validate.Params{"max": time.Hour})))
+
+	Ω(V.Var("datetime=2006-01-02", "2024-02-29")).Should(BeNil())
+	Ω(V.Var("datetime=2006-01-02", "2023-02-29")).Should(Equal(validate.NewError("datetime.invalid",
+		"Should be a time in the format 2006-01-02", validate.Params{"layout": "2006-01-02"})))
+	Ω(V.Var("datetime=15:04", "23:59")).Should(BeNil())
+	Ω(V.Var("datetime=15:04", "24:00")).ShouldNot(BeNil())
+}
+
+func TestEncodedStrings(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, s := range []string{"true", "false", "1", "0", "TRUE", "f"} {
+		Ω(boolValidator(s)).Should(BeNil(), s)
+	}
+	Ω(boolValidator("yes")).Should(Equal(validate.NewError("bool.invalid", "Should be true or false", nil)))
+
+	Ω(jsonValidator(`{"a": [1, 2, null]}`)).Should(BeNil())
+	Ω(jsonValidator([]byte(`"x"`))).Should(BeNil())
+	Ω(jsonValidator(`{"a": 1,}`)).Should(Equal(validate.NewError("json.invalid", "Should be valid JSON", nil)))
+	Ω(jsonValidator("")).ShouldNot(BeNil())
+
+	Ω(base64Validator("aGVsbG8=")).Should(BeNil())
+	Ω(base64Validator("")).Should(BeNil())
+	Ω(base64Validator("aGVsbG8")).ShouldNot(BeNil())
+	Ω(base64Validator("aGVsbG9=")).ShouldNot(BeNil()) /* non-zero padding bits */
+	Ω(base64Validator("-_8=")).ShouldNot(BeNil())
+
+	Ω(base64urlValidator("-_8=")).Should(BeNil())
+	Ω(base64urlValidator("-_8")).Should(BeNil())
+	Ω(base64urlValidator("+/8=")).Should(Equal(validate.NewError("base64url.invalid",
+		"Should be base64url encoded", nil)))
+
+	Ω(hexValidator("deadBEEF")).Should(BeNil())
+	Ω(hexValidator("abc")).Should(Equal(validate.NewError("hex.invalid", "Should be hex encoded", nil)))
+	Ω(hexValidator("0x00")).ShouldNot(BeNil())
+
+	type Config struct {
+		Timeout string   `validate:"duration=1s..1m"`
+		Workers string   `validate:"int=1..64"`
+		Debug   string   `validate:"bool"`
+		Since   string   `validate:"datetime=2006-01-02"`
+		Keys    []string `validate:"hex"`
+	}
+	Ω(V.Validate(Config{"30s", "8", "true", "2024-01-01", []string{"00ff"}})).Should(BeNil())
+	Ω(V.Validate(Config{"2m", "0", "nope", "01/01/2024", []string{"00ff", "zz"}})).Should(HaveLen(5))
+}
@@ -0,0 +1,271 @@
+package validators
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// cardRange is a range of issuer identification numbers of a card network:
+// numbers whose first len(lo) digits are between lo and hi.
+type cardRange struct {
+	network string
+	lo, hi  string
+}
+
+var cardRanges = []cardRange{
+	{"visa", "4", "4"},
+	{"mastercard", "51", "55"},
+	{"mastercard", "2221", "2720"},
+	{"amex", "34", "34"},
+	{"amex", "37", "37"},
+	{"discover", "6011", "6011"},
+	{"discover", "644", "649"},
+	{"discover", "65", "65"},
+	{"discover", "622126", "622925"},
+	{"jcb", "3528", "3589"},
+	{"dinersclub", "300", "305"},
+	{"dinersclub", "36", "36"},
+	{"dinersclub", "38", "39"},
+	{"unionpay", "62", "62"},
+	{"maestro", "5018", "5018"},
+	{"maestro", "5020", "5020"},
+	{"maestro", "5038", "5038"},
+	{"maestro", "5893", "5893"},
+	{"maestro", "6304", "6304"},
+	{"maestro", "6759", "6759"},
+	{"maestro", "6761", "6763"},
+	{"mir", "2200", "2204"},
+}
+
+/* Card number lengths by network, as ranges */
+var cardLengths = map[string][2]int{
+	"visa":       {13, 19},
+	"mastercard": {16, 16},
+	"amex":       {15, 15},
+	"discover":   {16, 19},
+	"jcb":        {16, 19},
+	"dinersclub": {14, 19},
+	"unionpay":   {16, 19},
+	"maestro":    {12, 19},
+	"mir":        {16, 19},
+}
+
+const minCardLen, maxCardLen = 12, 19
+
+var (
+	cardErr         = validate.NewError("creditcard.invalid", "Should be a card number", nil)
+	cardChecksumErr = validate.NewError("creditcard.checksum", "Invalid card number", nil)
+)
+
+// stripSeparators removes the spaces and dashes values are often written
+// with.
+func stripSeparators(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// luhn reports whether the digits pass the Luhn (mod 10) check.
+func luhn(digits string) bool {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// cardNetwork returns the network of a card number by its most specific
+// range, or an empty string.
+func cardNetwork(digits string) string {
+	network, best := "", 0
+	for _, r := range cardRanges {
+		if len(r.lo) > len(digits) || len(r.lo) <= best {
+			continue
+		}
+		if iin := digits[:len(r.lo)]; r.lo <= iin && iin <= r.hi {
+			network, best = r.network, len(r.lo)
+		}
+	}
+	return network
+}
+
+func creditCard(networks []string) (validate.ValidatorFn, error) {
+	for _, n := range networks {
+		if _, ok := cardLengths[n]; !ok {
+			return nil, fmt.Errorf("unknown card network %q", n)
+		}
+	}
+	networkErr := validate.NewError("creditcard.network",
+		"Card network should be one of: "+strings.Join(networks, ", "),
+		validate.Params{"networks": networks})
+
+	return validate.Strings(func(str string) interface{} {
+		digits := stripSeparators(str)
+		if !isDigits(digits) {
+			return cardErr
+		}
+
+		network := cardNetwork(digits)
+		if len(networks) > 0 {
+			allowed := false
+			for _, n := range networks {
+				allowed = allowed || n == network
+			}
+			if !allowed {
+				return networkErr
+			}
+		}
+
+		lengths, ok := cardLengths[network]
+		if !ok {
+			lengths = [2]int{minCardLen, maxCardLen}
+		}
+		if len(digits) < lengths[0] || len(digits) > lengths[1] {
+			return validate.NewError("creditcard.length", "Invalid card number length",
+				validate.Params{"network": network, "min": lengths[0], "max": lengths[1]})
+		}
+		if !luhn(digits) {
+			return cardChecksumErr
+		}
+		return nil
+	}), nil
+}
+
+func creditCardValidator(param string) (validate.ValidatorFn, error) {
+	var networks []string
+	if param != "" {
+		networks = strings.Split(param, "|")
+	}
+	return creditCard(networks)
+}
+
+// CreditCard returns a validator that checks that strings, byte arrays and
+// each element of string arrays are payment card numbers, possibly written
+// with spaces or dashes: their length must suit the network given by their
+// first digits, and they must pass the Luhn check. If networks are given,
+// the number must belong to one of them:
+//
+//	visa mastercard amex discover jcb dinersclub unionpay maestro mir
+//
+// Numbers of other networks are accepted when no networks are given, with
+// 12 to 19 digits. Errors tell the reason: "creditcard.invalid" for other
+// characters, "creditcard.network", "creditcard.length" and
+// "creditcard.checksum".
+//
+// The same validator is registered in V as creditcard, where networks are
+// given as a '|'-separated parameter: `creditcard=visa|mastercard`.
+//
+// CreditCard panics if a network is unknown.
+func CreditCard(networks ...string) validate.ValidatorFn {
+	return must(creditCard(networks))
+}
+
+/* IBAN lengths by country, from the SWIFT IBAN registry */
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28,
+	"PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24, "SC": 31,
+	"SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+var (
+	ibanErr         = validate.NewError("iban.invalid", "Should be an IBAN", nil)
+	ibanChecksumErr = validate.NewError("iban.checksum", "Invalid IBAN check digits", nil)
+)
+
+func isUpperAlnum(c byte) bool {
+	return 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// ibanMod97 computes the ISO 7064 MOD 97-10 remainder of an IBAN, moving
+// its first four characters to the end and letters to numbers from 10.
+func ibanMod97(iban string) int {
+	rem := 0
+	for _, c := range []byte(iban[4:] + iban[:4]) {
+		if c >= 'A' {
+			rem = (rem*100 + int(c-'A'+10)) % 97
+		} else {
+			rem = (rem*10 + int(c-'0')) % 97
+		}
+	}
+	return rem
+}
+
+// ibanValidator checks IBANs in either case, possibly grouped by spaces or
+// dashes: the country code, the length for the country and the check
+// digits. The structure of the account numbers is not checked.
+var ibanValidator = validate.Strings(func(str string) interface{} {
+	iban := strings.ToUpper(stripSeparators(str))
+	if len(iban) < 5 || iban[0] < 'A' || iban[0] > 'Z' || iban[1] < 'A' || iban[1] > 'Z' || !isDigits(iban[2:4]) {
+		return ibanErr
+	}
+	for i := 4; i < len(iban); i++ {
+		if !isUpperAlnum(iban[i]) {
+			return ibanErr
+		}
+	}
+
+	country := iban[:2]
+	length, ok := ibanLengths[country]
+	if !ok {
+		return validate.NewError("iban.country", "IBANs are not used in "+country,
+			validate.Params{"country": country})
+	}
+	if len(iban) != length {
+		return validate.NewError("iban.length",
+			"IBAN of "+country+" should have "+strconv.Itoa(length)+" characters",
+			validate.Params{"country": country, "length": length})
+	}
+	if ibanMod97(iban) != 1 {
+		return ibanChecksumErr
+	}
+	return nil
+})
+
+// bicValidator checks BICs (ISO 9362): a 4-letter institution code, a
+// country code, a 2-character location code and an optional 3-character
+// branch code, in upper case.
+var bicValidator = validate.Strings(func(str string) interface{} {
+	if len(str) != 8 && len(str) != 11 {
+		return validate.NewError("bic.invalid", "Should be a BIC", nil)
+	}
+	for i := 0; i < len(str); i++ {
+		if c := str[i]; !isUpperAlnum(c) || i < 6 && c <= '9' {
+			return validate.NewError("bic.invalid", "Should be a BIC", nil)
+		}
+	}
+	isoOnce.Do(loadISOTables)
+	if country := str[4:6]; !isoAlpha2[country] && country != "XK" {
+		return validate.NewError("bic.country", "Unknown BIC country: "+country,
+			validate.Params{"country": country})
+	}
+	return nil
+})
@@ -0,0 +1,107 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestCreditCard(t *testing.T) {
+	RegisterTestingT(t)
+
+	for number, network := range map[string]string{
+		"4111111111111111":    "visa",
+		"4222222222222":       "visa",
+		"5555555555554444":    "mastercard",
+		"2223000048400011":    "mastercard",
+		"378282246310005":     "amex",
+		"6011111111111117":    "discover",
+		"6221260000000000":    "discover",
+		"3530111333300000":    "jcb",
+		"30569309025904":      "dinersclub",
+		"6200000000000005":    "unionpay",
+		"6759649826438453":    "maestro",
+		"2200000000000004":    "mir",
+		"9999999999999995":    "",
+		"1234567890123456785": "",
+	} {
+		Ω(cardNetwork(number)).Should(Equal(network), number)
+	}
+
+	cc := CreditCard()
+	for _, s := range []string{
+		"4111111111111111",
+		"4111 1111 1111 1111",
+		"4111-1111-1111-1111",
+		"378282246310005",
+		"6011111111111117",
+		"9999999999999995",
+	} {
+		Ω(cc(s)).Should(BeNil(), s)
+	}
+	Ω(cc("")).Should(Equal(cardErr))
+	Ω(cc("4111.1111.1111.1111")).Should(Equal(cardErr))
+	Ω(cc("4111111111111112")).Should(Equal(cardChecksumErr))
+	Ω(cc("37828224631000")).Should(Equal(validate.NewError("creditcard.length", "Invalid card number length",
+		validate.Params{"network": "amex", "min": 15, "max": 15})))
+	Ω(cc("42")).Should(Equal(validate.NewError("creditcard.length", "Invalid card number length",
+		validate.Params{"network": "visa", "min": 13, "max": 19})))
+	Ω(cc([]string{"4111111111111111", "x"})).Should(Equal([]interface{}{nil, cardErr}))
+
+	vm := CreditCard("visa", "mastercard")
+	Ω(vm("5555 5555 5555 4444")).Should(BeNil())
+	Ω(vm("378282246310005")).Should(Equal(validate.NewError("creditcard.network",
+		"Card network should be one of: visa, mastercard",
+		validate.Params{"networks": []string{"visa", "mastercard"}})))
+	Ω(vm("9999999999999995")).ShouldNot(BeNil())
+	Ω(func() { CreditCard("diners") }).Should(Panic())
+}
+
+func TestIBAN(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, s := range []string{
+		"GB82WEST12345698765432",
+		"GB82 WEST 1234 5698 7654 32",
+		"gb82 west 1234 5698 7654 32",
+		"DE89370400440532013000",
+		"FR1420041010050500013M02606",
+		"NO9386011117947",
+		"MT84MALT011000012345MTLCAST001S",
+	} {
+		Ω(ibanValidator(s)).Should(BeNil(), s)
+	}
+	for _, s := range []string{"", "GB", "1282WEST12345698765432", "GBXXWEST12345698765432", "GB82WEST1234569876543_"} {
+		Ω(ibanValidator(s)).Should(Equal(ibanErr), s)
+	}
+	Ω(ibanValidator("US12345678901234")).Should(Equal(validate.NewError("iban.country",
+		"IBANs are not used in US", validate.Params{"country": "US"})))
+	Ω(ibanValidator("GB82WEST1234569876543")).Should(Equal(validate.NewError("iban.length",
+		"IBAN of GB should have 22 characters", validate.Params{"country": "GB", "length": 22})))
+	Ω(ibanValidator("GB83WEST12345698765432")).Should(Equal(ibanChecksumErr))
+}
+
+func TestBIC(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, s := range []string{"DEUTDEFF", "DEUTDEFF500", "NEDSZAJJXXX", "BKAUATWW"} {
+		Ω(bicValidator(s)).Should(BeNil(), s)
+	}
+	for _, s := range []string{"", "DEUTDEF", "DEUTDEFF5", "deutdeff", "DEU1DEFF", "DEUTD3FF", "DEUTDEFF-00"} {
+		Ω(bicValidator(s)).Should(Equal(validate.NewError("bic.invalid", "Should be a BIC", nil)), s)
+	}
+	Ω(bicValidator("DEUTZZFF")).Should(Equal(validate.NewError("bic.country", "Unknown BIC country: ZZ",
+		validate.Params{"country": "ZZ"})))
+
+	type Billing struct {
+		Card string `validate:"creditcard=visa|amex"`
+		IBAN string `validate:"iban"`
+		BIC  string `validate:"bic"`
+	}
+	Ω(V.Validate(Billing{"4111 1111 1111 1111", "DE89 3704 0044 0532 0130 00", "COBADEFFXXX"})).Should(BeNil())
+	errs := V.Validate(Billing{"5555555555554444", "DE89 3704 0044 0532 0130 01", "COBADEFFXXX"})
+	Ω(errs).Should(HaveLen(2))
+	Ω(errs["Card"].(validate.Error).Code).Should(Equal("creditcard.network"))
+	Ω(errs["IBAN"]).Should(Equal(ibanChecksumErr))
+}
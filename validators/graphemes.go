@@ -0,0 +1,199 @@
+package validators
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Grapheme cluster segmentation per UAX #29 (rules GB1 to GB13, without
+// the Indic conjunct rule GB9c), built on the tables of package unicode
+// plus the few properties it does not provide.
+
+type gcbProp int
+
+const (
+	gcbOther gcbProp = iota
+	gcbCR
+	gcbLF
+	gcbControl
+	gcbExtend
+	gcbZWJ
+	gcbRegionalIndicator
+	gcbPrepend
+	gcbSpacingMark
+	gcbL
+	gcbV
+	gcbT
+	gcbLV
+	gcbLVT
+)
+
+var gcbExtendExtra = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x200c, 0x200c, 1},
+	},
+	R32: []unicode.Range32{
+		{0x1f3fb, 0x1f3ff, 1}, /* emoji modifiers */
+		{0xe0020, 0xe007f, 1}, /* tags */
+	},
+}
+
+var gcbPrependTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x0600, 0x0605, 1},
+		{0x06dd, 0x06dd, 1},
+		{0x070f, 0x070f, 1},
+		{0x0890, 0x0891, 1},
+		{0x08e2, 0x08e2, 1},
+		{0x0d4e, 0x0d4e, 1},
+	},
+	R32: []unicode.Range32{
+		{0x110bd, 0x110bd, 1},
+		{0x110cd, 0x110cd, 1},
+		{0x111c2, 0x111c3, 1},
+		{0x1193f, 0x1193f, 1},
+		{0x11941, 0x11941, 1},
+		{0x11a3a, 0x11a3a, 1},
+		{0x11a84, 0x11a89, 1},
+		{0x11d46, 0x11d46, 1},
+	},
+}
+
+// extPictTable approximates Extended_Pictographic from emoji-data.txt.
+var extPictTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00a9, 0x00a9, 1}, {0x00ae, 0x00ae, 1}, {0x203c, 0x203c, 1},
+		{0x2049, 0x2049, 1}, {0x2122, 0x2122, 1}, {0x2139, 0x2139, 1},
+		{0x2194, 0x2199, 1}, {0x21a9, 0x21aa, 1}, {0x231a, 0x231b, 1},
+		{0x2328, 0x2328, 1}, {0x2388, 0x2388, 1}, {0x23cf, 0x23cf, 1},
+		{0x23e9, 0x23f3, 1}, {0x23f8, 0x23fa, 1}, {0x24c2, 0x24c2, 1},
+		{0x25aa, 0x25ab, 1}, {0x25b6, 0x25b6, 1}, {0x25c0, 0x25c0, 1},
+		{0x25fb, 0x25fe, 1}, {0x2600, 0x2605, 1}, {0x2607, 0x2612, 1},
+		{0x2614, 0x2685, 1}, {0x2690, 0x2705, 1}, {0x2708, 0x2712, 1},
+		{0x2714, 0x2714, 1}, {0x2716, 0x2716, 1}, {0x271d, 0x271d, 1},
+		{0x2721, 0x2721, 1}, {0x2728, 0x2728, 1}, {0x2733, 0x2734, 1},
+		{0x2744, 0x2744, 1}, {0x2747, 0x2747, 1}, {0x274c, 0x274c, 1},
+		{0x274e, 0x274e, 1}, {0x2753, 0x2755, 1}, {0x2757, 0x2757, 1},
+		{0x2763, 0x2767, 1}, {0x2795, 0x2797, 1}, {0x27a1, 0x27a1, 1},
+		{0x27b0, 0x27b0, 1}, {0x27bf, 0x27bf, 1}, {0x2934, 0x2935, 1},
+		{0x2b05, 0x2b07, 1}, {0x2b1b, 0x2b1c, 1}, {0x2b50, 0x2b50, 1},
+		{0x2b55, 0x2b55, 1}, {0x3030, 0x3030, 1}, {0x303d, 0x303d, 1},
+		{0x3297, 0x3297, 1}, {0x3299, 0x3299, 1},
+	},
+	R32: []unicode.Range32{
+		{0x1f000, 0x1f0ff, 1}, {0x1f10d, 0x1f10f, 1}, {0x1f12f, 0x1f12f, 1},
+		{0x1f16c, 0x1f171, 1}, {0x1f17e, 0x1f17f, 1}, {0x1f18e, 0x1f18e, 1},
+		{0x1f191, 0x1f19a, 1}, {0x1f1ad, 0x1f1e5, 1}, {0x1f201, 0x1f20f, 1},
+		{0x1f21a, 0x1f21a, 1}, {0x1f22f, 0x1f22f, 1}, {0x1f232, 0x1f23a, 1},
+		{0x1f23c, 0x1f23f, 1}, {0x1f249, 0x1f3fa, 1}, {0x1f400, 0x1f53d, 1},
+		{0x1f546, 0x1f64f, 1}, {0x1f680, 0x1f6ff, 1}, {0x1f774, 0x1f77f, 1},
+		{0x1f7d5, 0x1f7ff, 1}, {0x1f80c, 0x1f80f, 1}, {0x1f848, 0x1f84f, 1},
+		{0x1f85a, 0x1f85f, 1}, {0x1f888, 0x1f88f, 1}, {0x1f8ae, 0x1f8ff, 1},
+		{0x1f90c, 0x1f93a, 1}, {0x1f93c, 0x1f945, 1}, {0x1f947, 0x1faff, 1},
+		{0x1fc00, 0x1fffd, 1},
+	},
+}
+
+func gcbProperty(r rune) gcbProp {
+	switch {
+	case r == '\r':
+		return gcbCR
+	case r == '\n':
+		return gcbLF
+	case r == 0x200d:
+		return gcbZWJ
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return gcbRegionalIndicator
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return gcbLV
+		}
+		return gcbLVT
+	case r >= 0x1100 && r <= 0x115f, r >= 0xa960 && r <= 0xa97c:
+		return gcbL
+	case r >= 0x1160 && r <= 0x11a7, r >= 0xd7b0 && r <= 0xd7c6:
+		return gcbV
+	case r >= 0x11a8 && r <= 0x11ff, r >= 0xd7cb && r <= 0xd7fb:
+		return gcbT
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend, gcbExtendExtra):
+		return gcbExtend
+	case unicode.Is(gcbPrependTable, r):
+		return gcbPrepend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gcbControl
+	case unicode.Is(unicode.Mc, r), r == 0x0e33, r == 0x0eb3:
+		return gcbSpacingMark
+	}
+	return gcbOther
+}
+
+// graphemeBreak reports whether there is a cluster boundary between a
+// character with the property prev and one with the property next.
+// pictSeq is set when prev ends a sequence of an Extended_Pictographic
+// character followed by Extend* ZWJ; riOdd when prev is the odd-numbered
+// regional indicator of a run.
+func graphemeBreak(prev, next gcbProp, nextPict, pictSeq, riOdd bool) bool {
+	switch {
+	case prev == gcbCR && next == gcbLF: /* GB3 */
+		return false
+	case prev == gcbCR, prev == gcbLF, prev == gcbControl: /* GB4 */
+		return true
+	case next == gcbCR, next == gcbLF, next == gcbControl: /* GB5 */
+		return true
+	case prev == gcbL && (next == gcbL || next == gcbV || next == gcbLV || next == gcbLVT): /* GB6 */
+		return false
+	case (prev == gcbLV || prev == gcbV) && (next == gcbV || next == gcbT): /* GB7 */
+		return false
+	case (prev == gcbLVT || prev == gcbT) && next == gcbT: /* GB8 */
+		return false
+	case next == gcbExtend, next == gcbZWJ: /* GB9 */
+		return false
+	case next == gcbSpacingMark: /* GB9a */
+		return false
+	case prev == gcbPrepend: /* GB9b */
+		return false
+	case prev == gcbZWJ && pictSeq && nextPict: /* GB11 */
+		return false
+	case prev == gcbRegionalIndicator && next == gcbRegionalIndicator && riOdd: /* GB12, GB13 */
+		return false
+	}
+	return true /* GB999 */
+}
+
+// graphemeCount returns the number of extended grapheme clusters in s.
+// Invalid UTF-8 bytes count as one cluster each.
+func graphemeCount(s string) int {
+	n := 0
+	var prev gcbProp
+	pictSeq, inPict, riOdd := false, false, false
+
+	for i, r := range s {
+		p := gcbProperty(r)
+		if r == utf8.RuneError {
+			p = gcbControl
+		}
+		pict := unicode.Is(extPictTable, r)
+
+		if i == 0 || graphemeBreak(prev, p, pict, pictSeq, riOdd) {
+			n++
+		}
+
+		/* Track the state needed by GB11 and GB12/GB13 */
+		switch {
+		case pict:
+			inPict, pictSeq = true, false
+		case p == gcbExtend && inPict:
+		case p == gcbZWJ && inPict:
+			pictSeq, inPict = true, false
+		default:
+			inPict, pictSeq = false, false
+		}
+		if p == gcbRegionalIndicator {
+			riOdd = !riOdd
+		} else {
+			riOdd = false
+		}
+		prev = p
+	}
+	return n
+}
@@ -0,0 +1,133 @@
+package validators
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/PlanitarInc/validate"
+)
+
+/* Tolerated clock skew for identifiers embedding their creation time */
+const idClockSkew = 24 * time.Hour
+
+// parseUUID decodes a UUID in the canonical 8-4-4-4-12 hex form, in either
+// case.
+func parseUUID(s string) ([16]byte, bool) {
+	var u [16]byte
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, false
+	}
+	src := s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(u[:], []byte(src)); err != nil {
+		return u, false
+	}
+	return u, true
+}
+
+// uuidVersion returns the version of a UUID of the RFC 9562 variant; the
+// nil and max UUIDs have the versions 0 and 15.
+func uuidVersion(u [16]byte) (int, bool) {
+	switch u {
+	case [16]byte{}:
+		return 0, true
+	case [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}:
+		return 15, true
+	}
+	v := int(u[6] >> 4)
+	return v, u[8]&0xc0 == 0x80 && v >= 1 && v <= 8
+}
+
+/* Crockford's base32, the alphabet of ULIDs */
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func isCrockford(c byte) bool {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	for i := 0; i < len(crockford); i++ {
+		if crockford[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
+func isBase62(c byte) bool {
+	return '0' <= c && c <= '9' || 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z'
+}
+
+/* 2^160 - 1, the largest KSUID; base62 digits sort like their values */
+const maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+
+var (
+	uuidValidator = validate.Strings(func(str string) interface{} {
+		u, ok := parseUUID(str)
+		if _, known := uuidVersion(u); !ok || !known {
+			return validate.NewError("uuid.invalid", "Should be a UUID", nil)
+		}
+		return nil
+	})
+
+	uuid4Validator = validate.Strings(func(str string) interface{} {
+		u, ok := parseUUID(str)
+		if v, known := uuidVersion(u); !ok || !known || v != 4 {
+			return validate.NewError("uuid4.invalid", "Should be a version 4 UUID", nil)
+		}
+		return nil
+	})
+
+	// ulidValidator checks the length and alphabet of ULIDs, and that the
+	// 48-bit time does not overflow, as the first character could let it.
+	ulidValidator = validate.Strings(func(str string) interface{} {
+		if len(str) != 26 || str[0] > '7' {
+			return validate.NewError("ulid.invalid", "Should be a ULID", nil)
+		}
+		for i := 0; i < len(str); i++ {
+			if !isCrockford(str[i]) {
+				return validate.NewError("ulid.invalid", "Should be a ULID", nil)
+			}
+		}
+		return nil
+	})
+
+	ksuidValidator = validate.Strings(func(str string) interface{} {
+		if len(str) != len(maxKSUID) || str > maxKSUID {
+			return validate.NewError("ksuid.invalid", "Should be a KSUID", nil)
+		}
+		for i := 0; i < len(str); i++ {
+			if !isBase62(str[i]) {
+				return validate.NewError("ksuid.invalid", "Should be a KSUID", nil)
+			}
+		}
+		return nil
+	})
+
+	objectidValidator = validate.Strings(func(str string) interface{} {
+		if len(str) != 24 {
+			return validate.NewError("objectid.invalid", "Should be an ObjectID", nil)
+		}
+		if _, err := hex.DecodeString(str); err != nil {
+			return validate.NewError("objectid.invalid", "Should be an ObjectID", nil)
+		}
+		return nil
+	})
+)
+
+// uuid7Validator also checks that the embedded time, in milliseconds since
+// the Unix epoch, is not in the future by now.
+func uuid7Validator(now func() time.Time) validate.ValidatorFn {
+	return validate.Strings(func(str string) interface{} {
+		u, ok := parseUUID(str)
+		if v, known := uuidVersion(u); !ok || !known || v != 7 {
+			return validate.NewError("uuid7.invalid", "Should be a version 7 UUID", nil)
+		}
+		var ms int64
+		for _, b := range u[:6] {
+			ms = ms<<8 | int64(b)
+		}
+		if time.UnixMilli(ms).After(now().Add(idClockSkew)) {
+			return validate.NewError("uuid7.future", "UUID time should not be in the future", nil)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,97 @@
+package validators
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestUUIDValidators(t *testing.T) {
+	RegisterTestingT(t)
+
+	v1 := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	v4 := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	v7 := "01890a5d-ac96-774b-bcce-b302099a8057"
+	for _, s := range []string{v1, v4, v7, "F47AC10B-58CC-4372-A567-0E02B2C3D479",
+		"00000000-0000-0000-0000-000000000000", "ffffffff-ffff-ffff-ffff-ffffffffffff"} {
+		Ω(uuidValidator(s)).Should(BeNil(), s)
+	}
+	for _, s := range []string{
+		"",
+		"f47ac10b58cc4372a5670e02b2c3d479",
+		"{f47ac10b-58cc-4372-a567-0e02b2c3d479}",
+		"f47ac10b-58cc-4372-a567-0e02b2c3d47g",
+		"f47ac10b-58cc-4372-a567_0e02b2c3d479",
+		"f47ac10b-58cc-0372-a567-0e02b2c3d479", /* version 0 */
+		"f47ac10b-58cc-4372-c567-0e02b2c3d479", /* Microsoft variant */
+	} {
+		Ω(uuidValidator(s)).ShouldNot(BeNil(), s)
+	}
+	Ω(uuidValidator([]byte(v4))).Should(BeNil())
+	Ω(uuidValidator([]string{v4, "x"})).Should(Equal([]interface{}{nil,
+		validate.NewError("uuid.invalid", "Should be a UUID", nil)}))
+	Ω(uuidValidator(42)).ShouldNot(BeNil())
+
+	Ω(uuid4Validator(v4)).Should(BeNil())
+	Ω(uuid4Validator(v1)).Should(Equal(validate.NewError("uuid4.invalid", "Should be a version 4 UUID", nil)))
+	Ω(uuid4Validator("00000000-0000-0000-0000-000000000000")).ShouldNot(BeNil())
+
+	uuid7 := V["uuid7"]
+	Ω(uuid7(v7)).Should(BeNil())
+	Ω(uuid7(v4)).Should(Equal(validate.NewError("uuid7.invalid", "Should be a version 7 UUID", nil)))
+	ms := time.Now().Add(48 * time.Hour).UnixMilli()
+	future := fmt.Sprintf("%08x-%04x-7000-8000-000000000000", ms>>16, ms&0xffff)
+	Ω(uuid7(future)).Should(Equal(validate.NewError("uuid7.future",
+		"UUID time should not be in the future", nil)))
+
+	later := WithClock(V, func() time.Time { return time.Now().Add(72 * time.Hour) })
+	Ω(later["uuid7"](future)).Should(BeNil())
+	Ω(WithClock(V, func() time.Time { return time.UnixMilli(0) })["uuid7"](v7)).ShouldNot(BeNil())
+}
+
+func TestSortableIDValidators(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(ulidValidator("01ARZ3NDEKTSV4RRFFQ69G5FAV")).Should(BeNil())
+	Ω(ulidValidator("01arz3ndektsv4rrffq69g5fav")).Should(BeNil())
+	Ω(ulidValidator("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")).Should(BeNil())
+	for _, s := range []string{
+		"",
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",   /* too short */
+		"01ARZ3NDEKTSV4RRFFQ69G5FAVX", /* too long */
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",  /* U is not in the alphabet */
+		"8ZZZZZZZZZZZZZZZZZZZZZZZZZ",  /* time overflow */
+	} {
+		Ω(ulidValidator(s)).Should(Equal(validate.NewError("ulid.invalid", "Should be a ULID", nil)), s)
+	}
+
+	Ω(ksuidValidator("0ujtsYcgvSTl8PAuAdqWYSMnLOv")).Should(BeNil())
+	Ω(ksuidValidator("000000000000000000000000000")).Should(BeNil())
+	Ω(ksuidValidator(maxKSUID)).Should(BeNil())
+	for _, s := range []string{
+		"",
+		"0ujtsYcgvSTl8PAuAdqWYSMnLO",
+		"0ujtsYcgvSTl8PAuAdqWYSMnLO-",
+		"aWgEPTl1tmebfsQzFP4bxwgy80W", /* overflow */
+		"zzzzzzzzzzzzzzzzzzzzzzzzzzz",
+	} {
+		Ω(ksuidValidator(s)).Should(Equal(validate.NewError("ksuid.invalid", "Should be a KSUID", nil)), s)
+	}
+
+	Ω(objectidValidator("507f1f77bcf86cd799439011")).Should(BeNil())
+	Ω(objectidValidator("507F1F77BCF86CD799439011")).Should(BeNil())
+	Ω(objectidValidator("507f1f77bcf86cd79943901")).ShouldNot(BeNil())
+	Ω(objectidValidator("507f1f77bcf86cd79943901z")).ShouldNot(BeNil())
+
+	type X struct {
+		ID     string   `validate:"uuid4"`
+		Events []string `validate:"ulid"`
+		Doc    []byte   `validate:"objectid"`
+	}
+	Ω(V.Validate(X{"f47ac10b-58cc-4372-a567-0e02b2c3d479", []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		[]byte("507f1f77bcf86cd799439011")})).Should(BeNil())
+	Ω(V.Validate(X{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", nil, []byte("x")})).Should(HaveLen(2))
+}
@@ -0,0 +1,226 @@
+package validators
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/PlanitarInc/validate"
+	"golang.org/x/text/language"
+)
+
+// ISODataVersion is the date of the ISO 3166 and ISO 4217 tables bundled
+// with the package.
+const ISODataVersion = "2025-07"
+
+var (
+	//go:embed data/iso3166-1.txt
+	iso3166Data string
+	//go:embed data/iso3166-2.txt
+	iso3166SubData string
+	//go:embed data/iso4217.txt
+	iso4217Data string
+)
+
+var (
+	isoOnce      sync.Once
+	isoAlpha2    map[string]bool
+	isoAlpha3    map[string]bool
+	isoCurrency  map[string]bool
+	subdivisions map[string]map[string]bool
+)
+
+// isoFields calls fn with the fields of each line of a data table, skipping
+// comments.
+func isoFields(data string, fn func([]string)) {
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		if f := strings.Fields(sc.Text()); len(f) > 0 && !strings.HasPrefix(f[0], "#") {
+			fn(f)
+		}
+	}
+}
+
+func loadISOTables() {
+	isoAlpha2, isoAlpha3 = map[string]bool{}, map[string]bool{}
+	isoFields(iso3166Data, func(f []string) {
+		isoAlpha2[f[0]], isoAlpha3[f[1]] = true, true
+	})
+
+	isoCurrency = map[string]bool{}
+	isoFields(iso4217Data, func(f []string) {
+		for _, code := range f {
+			isoCurrency[code] = true
+		}
+	})
+
+	subdivisions = map[string]map[string]bool{}
+	isoFields(iso3166SubData, func(f []string) {
+		if subdivisions[f[0]] == nil {
+			subdivisions[f[0]] = map[string]bool{}
+		}
+		for _, code := range f[1:] {
+			subdivisions[f[0]][code] = true
+		}
+	})
+}
+
+func isSubdivisionCode(c byte) bool {
+	return 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// isSubdivision reports whether s is an ISO 3166-2 code: a country code, a
+// hyphen and one to three letters or digits, which are checked against the
+// table for the countries it covers.
+func isSubdivision(s string) bool {
+	country, sub, ok := strings.Cut(s, "-")
+	if !ok || !isoAlpha2[country] || len(sub) == 0 || len(sub) > 3 {
+		return false
+	}
+	for i := 0; i < len(sub); i++ {
+		if !isSubdivisionCode(sub[i]) {
+			return false
+		}
+	}
+	if codes, ok := subdivisions[country]; ok {
+		return codes[sub]
+	}
+	return true
+}
+
+func isLanguageTag(s string) bool {
+	if strings.ContainsRune(s, '_') {
+		return false
+	}
+	_, err := language.Parse(s)
+	return err == nil
+}
+
+// isoStandard describes the codes checked by an ISO validator.
+type isoStandard struct {
+	err   validate.Error
+	valid func(s string) bool
+	// allowed checks the entries of allowlists and covers reports whether
+	// an entry allows s, for standards where entries may be broader than
+	// codes. They default to valid and equality.
+	allowed func(entry string) bool
+	covers  func(entry, s string) bool
+}
+
+func isoEqual(entry, s string) bool { return entry == s }
+
+var isoStandards = map[string]isoStandard{
+	"iso3166_alpha2": {
+		err:   validate.NewError("country.invalid", "Should be an ISO 3166-1 alpha-2 country code", nil),
+		valid: func(s string) bool { return isoAlpha2[s] },
+	},
+	"iso3166_alpha3": {
+		err:   validate.NewError("country.invalid", "Should be an ISO 3166-1 alpha-3 country code", nil),
+		valid: func(s string) bool { return isoAlpha3[s] },
+	},
+	"iso4217": {
+		err:   validate.NewError("currency.invalid", "Should be an ISO 4217 currency code", nil),
+		valid: func(s string) bool { return isoCurrency[s] },
+	},
+	/* Subdivisions may be allowed by country: `iso3166_2=US|CA` */
+	"iso3166_2": {
+		err:   validate.NewError("subdivision.invalid", "Should be an ISO 3166-2 subdivision code", nil),
+		valid: isSubdivision,
+		allowed: func(entry string) bool {
+			return isoAlpha2[entry] || isSubdivision(entry)
+		},
+		covers: func(entry, s string) bool {
+			return entry == s || strings.HasPrefix(s, entry+"-")
+		},
+	},
+	/* Tags are allowed with their prefixes: `bcp47=en|fr` allows en-US */
+	"bcp47": {
+		err:   validate.NewError("language.invalid", "Should be a BCP 47 language tag", nil),
+		valid: isLanguageTag,
+		covers: func(entry, s string) bool {
+			entry, s = strings.ToLower(entry), strings.ToLower(s)
+			return entry == s || strings.HasPrefix(s, entry+"-")
+		},
+	},
+}
+
+func isoCode(name string, allowed []string) (validate.ValidatorFn, error) {
+	std, ok := isoStandards[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown ISO standard %q", name)
+	}
+	isoOnce.Do(loadISOTables)
+
+	check, covers := std.allowed, std.covers
+	if check == nil {
+		check = std.valid
+	}
+	if covers == nil {
+		covers = isoEqual
+	}
+	for _, entry := range allowed {
+		if !check(entry) {
+			return nil, fmt.Errorf("invalid %s code %q", name, entry)
+		}
+	}
+	allowedErr := validate.NewError(strings.TrimSuffix(std.err.Code, ".invalid")+".not_allowed",
+		"Should be one of: "+strings.Join(allowed, ", "),
+		validate.Params{"allowed": allowed})
+
+	return validate.Strings(func(str string) interface{} {
+		if !std.valid(str) {
+			return std.err
+		}
+		if len(allowed) == 0 {
+			return nil
+		}
+		for _, entry := range allowed {
+			if covers(entry, str) {
+				return nil
+			}
+		}
+		return allowedErr
+	}), nil
+}
+
+func isoValidator(name string) func(param string) (validate.ValidatorFn, error) {
+	return func(param string) (validate.ValidatorFn, error) {
+		var allowed []string
+		if param != "" {
+			allowed = strings.Split(param, "|")
+		}
+		return isoCode(name, allowed)
+	}
+}
+
+// ISOCode returns a validator that checks that strings, byte arrays and
+// each element of string arrays are codes of the named standard:
+//
+//	iso3166_alpha2  ISO 3166-1 alpha-2 country codes: US
+//	iso3166_alpha3  ISO 3166-1 alpha-3 country codes: USA
+//	iso4217         ISO 4217 currency codes: USD
+//	iso3166_2       ISO 3166-2 subdivision codes: US-CA
+//	bcp47           BCP 47 language tags: en-US
+//
+// ISO codes are checked against the tables bundled with the package, see
+// ISODataVersion, and are upper case. The ISO 3166-2 table only covers the
+// subdivisions of Australia, Brazil, Canada, China, Germany, India, Japan,
+// Mexico and the United States; for the other countries, any code of one
+// to three letters or digits is accepted.
+// Language tags are checked by golang.org/x/text/language, in any case.
+//
+// If allowed values are given, the value must also be one of them. Allowed
+// subdivisions may be given by country, "US" allowing "US-CA", and allowed
+// language tags cover longer tags, "en" allowing "en-US".
+//
+// The same validators are registered in V under the names of the
+// standards, where allowed values are given as a '|'-separated parameter:
+// `iso4217=USD|EUR`.
+//
+// ISOCode panics if the standard is unknown or an allowed value is not a
+// valid code.
+func ISOCode(standard string, allowed ...string) validate.ValidatorFn {
+	return must(isoCode(standard, allowed))
+}
@@ -0,0 +1,96 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestISOCode(t *testing.T) {
+	RegisterTestingT(t)
+
+	countryErr := validate.NewError("country.invalid", "Should be an ISO 3166-1 alpha-2 country code", nil)
+	alpha2 := ISOCode("iso3166_alpha2")
+	Ω(alpha2("US")).Should(BeNil())
+	Ω(alpha2([]byte("SS"))).Should(BeNil())
+	Ω(alpha2("us")).Should(Equal(countryErr))
+	Ω(alpha2("UK")).Should(Equal(countryErr))
+	Ω(alpha2("USA")).Should(Equal(countryErr))
+	Ω(alpha2([]string{"DE", "XX"})).Should(Equal([]interface{}{nil, countryErr}))
+
+	alpha3 := ISOCode("iso3166_alpha3")
+	Ω(alpha3("USA")).Should(BeNil())
+	Ω(alpha3("US")).ShouldNot(BeNil())
+	Ω(alpha3("XKX")).ShouldNot(BeNil())
+
+	currency := ISOCode("iso4217")
+	Ω(currency("EUR")).Should(BeNil())
+	Ω(currency("XCG")).Should(BeNil())
+	Ω(currency("HRK")).Should(Equal(validate.NewError("currency.invalid", "Should be an ISO 4217 currency code", nil)))
+	Ω(currency("usd")).ShouldNot(BeNil())
+
+	sub := ISOCode("iso3166_2")
+	for _, s := range []string{"US-CA", "US-DC", "CA-QC", "JP-13", "MX-CMX", "FR-75C", "FR-IDF", "GB-ENG", "IT-RM"} {
+		Ω(sub(s)).Should(BeNil(), s)
+	}
+	for _, s := range []string{"", "US", "US-", "US-XX", "XX-CA", "FR-ABCD", "FR-a1", "us-ca"} {
+		Ω(sub(s)).Should(Equal(validate.NewError("subdivision.invalid",
+			"Should be an ISO 3166-2 subdivision code", nil)), s)
+	}
+
+	lang := ISOCode("bcp47")
+	for _, s := range []string{"en", "en-US", "EN-us", "zh-Hant-TW", "sr-Latn", "es-419", "de-CH-1996"} {
+		Ω(lang(s)).Should(BeNil(), s)
+	}
+	for _, s := range []string{"", "en_US", "english", "en--US", "xx-YY"} {
+		Ω(lang(s)).Should(Equal(validate.NewError("language.invalid", "Should be a BCP 47 language tag", nil)), s)
+	}
+
+	Ω(func() { ISOCode("iso639") }).Should(Panic())
+	Ω(func() { ISOCode("iso4217", "EURO") }).Should(Panic())
+	Ω(func() { ISOCode("iso3166_2", "US-XX") }).Should(Panic())
+}
+
+func TestISOCodeAllowed(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(ISOCode("iso4217", "USD", "EUR")("EUR")).Should(BeNil())
+	Ω(ISOCode("iso4217", "USD", "EUR")("GBP")).Should(Equal(validate.NewError("currency.not_allowed",
+		"Should be one of: USD, EUR", validate.Params{"allowed": []string{"USD", "EUR"}})))
+	Ω(ISOCode("iso4217", "USD")("usd")).Should(Equal(isoStandards["iso4217"].err))
+
+	states := ISOCode("iso3166_2", "US", "CA-QC")
+	Ω(states("US-NY")).Should(BeNil())
+	Ω(states("CA-QC")).Should(BeNil())
+	Ω(states("CA-ON")).ShouldNot(BeNil())
+
+	langs := ISOCode("bcp47", "en", "pt-BR")
+	Ω(langs("en-GB")).Should(BeNil())
+	Ω(langs("pt-br")).Should(BeNil())
+	Ω(langs("pt-PT")).ShouldNot(BeNil())
+	Ω(langs("eng")).ShouldNot(BeNil())
+
+	type Address struct {
+		Country  string `validate:"iso3166_alpha2=US|CA"`
+		Region   string `validate:"iso3166_2=US|CA"`
+		Currency string `validate:"iso4217"`
+		Locale   string `validate:"bcp47=en|fr"`
+		ISO3     string `validate:"iso3166_alpha3"`
+	}
+	Ω(V.Validate(Address{"CA", "CA-QC", "CAD", "fr-CA", "CAN"})).Should(BeNil())
+	errs := V.Validate(Address{"MX", "MX-CMX", "MXN", "es-MX", "MEX"})
+	Ω(errs).Should(HaveLen(3))
+	Ω(errs["Country"]).Should(MatchError("Should be one of: US, CA"))
+	Ω(errs["Locale"].(validate.Error).Code).Should(Equal("language.not_allowed"))
+	Ω(V.Var("iso3166_alpha2=USA", "US").(validate.Error).Code).Should(Equal("validator.params"))
+}
+
+func TestISODataVersion(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, data := range []string{iso3166Data, iso3166SubData, iso4217Data} {
+		Ω(strings.SplitN(data, "\n", 2)[0]).Should(ContainSubstring("version " + ISODataVersion))
+	}
+}
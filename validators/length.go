@@ -0,0 +1,134 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// LengthUnit is the unit in which Length measures text.
+type LengthUnit int
+
+const (
+	// Bytes counts the bytes of the UTF-8 encoding, as limits of database
+	// columns usually do.
+	Bytes LengthUnit = iota
+	// Runes counts Unicode code points, like StrLimit.
+	Runes
+	// UTF16 counts UTF-16 code units, like String.prototype.length in
+	// JavaScript: characters outside the Basic Multilingual Plane count
+	// twice.
+	UTF16
+	// Graphemes counts extended grapheme clusters as defined by UAX #29,
+	// i.e. user-perceived characters: an "e" with a combining accent,
+	// a flag or an emoji ZWJ sequence count once.
+	Graphemes
+)
+
+var lengthUnits = [...]struct {
+	name, text string
+	count      func(string) int
+}{
+	Bytes:     {"bytes", "bytes", func(s string) int { return len(s) }},
+	Runes:     {"runes", "code points", utf8.RuneCountInString},
+	UTF16:     {"utf16", "UTF-16 code units", utf16Len},
+	Graphemes: {"graphemes", "characters", graphemeCount},
+}
+
+func (u LengthUnit) String() string {
+	if u < 0 || int(u) >= len(lengthUnits) {
+		return "LengthUnit(" + strconv.Itoa(int(u)) + ")"
+	}
+	return lengthUnits[u].name
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+func length(unit LengthUnit, min, max uint) (validate.ValidatorFn, error) {
+	if unit < 0 || int(unit) >= len(lengthUnits) {
+		return nil, fmt.Errorf("unknown length unit %d", int(unit))
+	}
+	if min > max {
+		return nil, fmt.Errorf("empty range %d..%d", min, max)
+	}
+
+	u := lengthUnits[unit]
+	minErr := validate.NewError("string.too_short",
+		fmt.Sprintf("Minimum length is %d %s", min, u.text),
+		validate.Params{"min": min, "unit": u.name})
+	maxErr := validate.NewError("string.too_long",
+		fmt.Sprintf("Maximum length is %d %s", max, u.text),
+		validate.Params{"max": max, "unit": u.name})
+
+	return validate.Strings(func(str string) interface{} {
+		n := uint(u.count(str))
+		if n < min {
+			return minErr
+		}
+		if n > max {
+			return maxErr
+		}
+		return nil
+	}), nil
+}
+
+// lengthValidator returns a constructor of validators for the unit that
+// take the bounds as "min..max", where either bound may be omitted, or as
+// a single exact length.
+func lengthValidator(unit LengthUnit) func(param string) (validate.ValidatorFn, error) {
+	return func(param string) (validate.ValidatorFn, error) {
+		lo, hi, isRange := strings.Cut(param, "..")
+		if !isRange {
+			hi = lo
+		}
+		if strings.TrimSpace(lo) == "" && strings.TrimSpace(hi) == "" {
+			return nil, errors.New(`expected a length or a range as "min..max"`)
+		}
+
+		min, max := uint(0), uint(math.MaxUint)
+		if s := strings.TrimSpace(lo); s != "" {
+			n, err := strconv.ParseUint(s, 10, 0)
+			if err != nil {
+				return nil, fmt.Errorf("invalid length %q", s)
+			}
+			min = uint(n)
+		}
+		if s := strings.TrimSpace(hi); s != "" {
+			n, err := strconv.ParseUint(s, 10, 0)
+			if err != nil {
+				return nil, fmt.Errorf("invalid length %q", s)
+			}
+			max = uint(n)
+		}
+		return length(unit, min, max)
+	}
+}
+
+// Length returns a validator that checks that the length of strings, byte
+// arrays and each element of string arrays, measured in unit, is between
+// min and max, inclusive. Byte arrays are taken as UTF-8 text; invalid
+// bytes count as one rune, UTF-16 code unit or grapheme each.
+//
+// The same validators are registered in V as bytelen, runelen, utf16len and
+// graphemelen, with the bounds as a parameter: `graphemelen=1..20`,
+// `bytelen=..255` or `runelen=2` for an exact length.
+//
+// Length panics if the unit is unknown or min is greater than max.
+func Length(unit LengthUnit, min, max uint) validate.ValidatorFn {
+	return must(length(unit, min, max))
+}
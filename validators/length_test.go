@@ -0,0 +1,95 @@
+package validators
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestGraphemeCount(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, c := range []struct {
+		str string
+		n   int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"\r\n", 1},
+		{"\n\r", 2},
+		{"e\u0301", 1},              /* e + combining acute */
+		{"\u0301", 1},               /* lone combining mark */
+		{"\U0001F1FA\U0001F1F8", 1}, /* flag: US */
+		{"\U0001F1FA\U0001F1F8\U0001F1EC", 2},
+		{"\U0001F44D\U0001F3FD", 1}, /* thumbs up + skin tone */
+		{"\U0001F468\u200d\U0001F469\u200d\U0001F467", 1}, /* family ZWJ sequence */
+		{"a\u200d\U0001F469", 2},                          /* ZWJ after a non-pictograph */
+		{"\u1100\u1161\u11a8", 1},                         /* conjoining jamo */
+		{"\uac00\u11a8", 1},                               /* LV + T */
+		{"\u0915\u093f", 1},                               /* Devanagari consonant + spacing mark */
+		{"\u0600\u0661", 1},                               /* prepended concatenation mark */
+		{"a\u200bb", 3},
+		{"\xff\xfe", 2},
+	} {
+		Ω(graphemeCount(c.str)).Should(Equal(c.n), "%+q", c.str)
+	}
+}
+
+func TestLength(t *testing.T) {
+	RegisterTestingT(t)
+
+	family := "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+	for _, c := range []struct {
+		unit LengthUnit
+		n    int
+	}{
+		{Bytes, 18},
+		{Runes, 5},
+		{UTF16, 8},
+		{Graphemes, 1},
+	} {
+		Ω(Length(c.unit, uint(c.n), uint(c.n))(family)).Should(BeNil(), "%s", c.unit)
+		Ω(Length(c.unit, 0, uint(c.n-1))(family)).Should(Equal(validate.NewError("string.too_long",
+			"Maximum length is "+strconv.Itoa(c.n-1)+" "+lengthUnits[c.unit].text,
+			validate.Params{"max": uint(c.n - 1), "unit": c.unit.String()})), "%s", c.unit)
+		Ω(Length(c.unit, uint(c.n+1), 100)(family)).Should(Equal(validate.NewError("string.too_short",
+			"Minimum length is "+strconv.Itoa(c.n+1)+" "+lengthUnits[c.unit].text,
+			validate.Params{"min": uint(c.n + 1), "unit": c.unit.String()})), "%s", c.unit)
+	}
+
+	v := Length(Graphemes, 1, 2)
+	Ω(v([]byte("ab"))).Should(BeNil())
+	Ω(v([]string{"a", "", "e\u0301e\u0301"})).Should(Equal([]interface{}{nil,
+		validate.NewError("string.too_short", "Minimum length is 1 characters",
+			validate.Params{"min": uint(1), "unit": "graphemes"}), nil}))
+	Ω(v(1)).Should(Equal(validate.NewError("string.type", "Should be a string or byte array", nil)))
+
+	Ω(func() { Length(Bytes, 2, 1) }).Should(Panic())
+	Ω(func() { Length(LengthUnit(9), 0, 1) }).Should(Panic())
+	Ω(LengthUnit(9).String()).Should(Equal("LengthUnit(9)"))
+}
+
+func TestLengthTags(t *testing.T) {
+	RegisterTestingT(t)
+
+	type X struct {
+		Column string `validate:"bytelen=..4"`
+		Label  string `validate:"graphemelen=1..2"`
+		Code   string `validate:"runelen=2"`
+		JS     string `validate:"utf16len=2.."`
+	}
+	Ω(V.Validate(X{Column: "\u00e9\u00e9", Label: "\U0001F1FA\U0001F1F8", Code: "\u00e9\u00e9", JS: "\U0001F600"})).Should(BeNil())
+
+	errs := V.Validate(X{Column: "\u00e9\u00e9\u00e9", Label: "", Code: "abc", JS: "a"})
+	Ω(errs).Should(HaveLen(4))
+	Ω(errs["Column"]).Should(MatchError("Maximum length is 4 bytes"))
+	Ω(errs["Label"]).Should(MatchError("Minimum length is 1 characters"))
+	Ω(errs["Code"]).Should(MatchError("Maximum length is 2 code points"))
+	Ω(errs["JS"]).Should(MatchError("Minimum length is 2 UTF-16 code units"))
+
+	for _, tag := range []string{"bytelen", "bytelen=..", "bytelen=a..2", "bytelen=3..1", "bytelen=-1"} {
+		Ω(V.Var(tag, "a").(validate.Error).Code).Should(Equal("validator.params"), tag)
+	}
+}
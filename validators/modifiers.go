@@ -0,0 +1,49 @@
+package validators
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// StrModifier returns a modifier (see validate.Modified) that applies fn to
+// strings, byte arrays and each element of string arrays. Named types are
+// preserved.
+func StrModifier(fn func(string) string) validate.ValidatorFn {
+	return validate.Typed(func(src interface{}) interface{} {
+		switch src.(type) {
+		case string:
+			return validate.Modified(fn(src.(string)))
+		case []byte:
+			return validate.Modified([]byte(fn(string(src.([]byte)))))
+		case []string:
+			arr := src.([]string)
+			res := make([]string, len(arr))
+			for i := range arr {
+				res[i] = fn(arr[i])
+			}
+			return validate.Modified(res)
+		}
+
+		val := reflect.ValueOf(src)
+		if val.Kind() != reflect.String {
+			return strTypeErr
+		}
+		res := reflect.New(val.Type()).Elem()
+		res.SetString(fn(val.String()))
+		return validate.Modified(res.Interface())
+	}, isStrModifierType)
+}
+
+// isStrModifierType reports whether StrModifier takes values of type t.
+func isStrModifierType(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t == reflect.TypeOf([]byte(nil)) || t == reflect.TypeOf([]string(nil))
+}
+
+// collapseSpace trims s and replaces every run of white space inside it
+// with a single space.
+func collapseSpace(s string) string {
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+}
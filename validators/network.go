@@ -0,0 +1,135 @@
+package validators
+
+import (
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// isHostname reports whether s is a host name as defined by RFC 1123: dot
+// separated labels of letters, digits and hyphens, not starting or ending
+// with a hyphen, 63 characters per label and 253 in total. A trailing dot
+// is allowed.
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isFQDN reports whether s is a host name of at least two labels whose top
+// level label is not numeric, so that it cannot be taken for an address.
+func isFQDN(s string) bool {
+	if !isHostname(s) {
+		return false
+	}
+	s = strings.TrimSuffix(s, ".")
+	i := strings.LastIndexByte(s, '.')
+	if i < 0 {
+		return false
+	}
+	tld := s[i+1:]
+	return strings.Trim(tld, "0123456789") != ""
+}
+
+func isPort(s string) bool {
+	if s == "" || s[0] == '+' || len(s) > 1 && s[0] == '0' {
+		return false
+	}
+	n, err := strconv.ParseUint(s, 10, 16)
+	return err == nil && n > 0
+}
+
+// parseIP parses an IP address without a zone.
+func parseIP(s string) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(s)
+	return addr, err == nil && addr.Zone() == ""
+}
+
+var hostportErr = validate.NewError("hostport.invalid", "Should be a host and a port", nil)
+
+var (
+	ipValidator = validate.Strings(func(str string) interface{} {
+		if _, ok := parseIP(str); !ok {
+			return validate.NewError("ip.invalid", "Should be an IP address", nil)
+		}
+		return nil
+	})
+
+	ipv4Validator = validate.Strings(func(str string) interface{} {
+		if addr, ok := parseIP(str); !ok || !addr.Is4() {
+			return validate.NewError("ipv4.invalid", "Should be an IPv4 address", nil)
+		}
+		return nil
+	})
+
+	ipv6Validator = validate.Strings(func(str string) interface{} {
+		if addr, ok := parseIP(str); !ok || !addr.Is6() {
+			return validate.NewError("ipv6.invalid", "Should be an IPv6 address", nil)
+		}
+		return nil
+	})
+
+	cidrValidator = validate.Strings(func(str string) interface{} {
+		if p, err := netip.ParsePrefix(str); err != nil || p.Addr().Zone() != "" {
+			return validate.NewError("cidr.invalid", "Should be an address range in CIDR notation", nil)
+		}
+		return nil
+	})
+
+	macValidator = validate.Strings(func(str string) interface{} {
+		if _, err := net.ParseMAC(str); err != nil {
+			return validate.NewError("mac.invalid", "Should be a MAC address", nil)
+		}
+		return nil
+	})
+
+	hostnameValidator = validate.Strings(func(str string) interface{} {
+		if !isHostname(str) {
+			return validate.NewError("hostname.invalid", "Should be a host name", nil)
+		}
+		return nil
+	})
+
+	fqdnValidator = validate.Strings(func(str string) interface{} {
+		if !isFQDN(str) {
+			return validate.NewError("fqdn.invalid", "Should be a fully qualified domain name", nil)
+		}
+		return nil
+	})
+
+	/* IPv6 addresses need brackets: "[::1]:80" */
+	hostportValidator = validate.Strings(func(str string) interface{} {
+		host, port, err := net.SplitHostPort(str)
+		if err != nil || !isPort(port) {
+			return hostportErr
+		}
+		if _, ok := parseIP(host); !ok && !isHostname(host) {
+			return hostportErr
+		}
+		return nil
+	})
+
+	/* Ports held in integer fields are checked by range=1..65535 */
+	portValidator = validate.Strings(func(str string) interface{} {
+		if !isPort(str) {
+			return validate.NewError("port.invalid", "Should be a port number", nil)
+		}
+		return nil
+	})
+)
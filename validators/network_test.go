@@ -0,0 +1,79 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkValidators(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, c := range []struct {
+		name string
+		code string
+		ok   []string
+		bad  []string
+	}{
+		{"ip", "ip.invalid",
+			[]string{"192.168.0.1", "::1", "2001:db8::1", "::ffff:10.0.0.1"},
+			[]string{"", "256.0.0.1", "01.2.3.4", "1.2.3", "fe80::1%eth0", "example.com"}},
+		{"ipv4", "ipv4.invalid",
+			[]string{"0.0.0.0", "8.8.8.8"},
+			[]string{"::1", "::ffff:10.0.0.1", "1.2.3.4.5"}},
+		{"ipv6", "ipv6.invalid",
+			[]string{"::", "2001:db8::1", "::ffff:10.0.0.1"},
+			[]string{"10.0.0.1", "2001:db8:::1", "[::1]"}},
+		{"cidr", "cidr.invalid",
+			[]string{"10.0.0.0/8", "10.1.2.3/24", "2001:db8::/32", "0.0.0.0/0"},
+			[]string{"10.0.0.0", "10.0.0.0/33", "2001:db8::/129", "fe80::%eth0/64"}},
+		{"mac", "mac.invalid",
+			[]string{"00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E", "001a.2b3c.4d5e"},
+			[]string{"00:1a:2b:3c:4d", "00:1a:2b:3c:4d:zz", "00:1a-2b:3c:4d:5e"}},
+		{"hostname", "hostname.invalid",
+			[]string{"localhost", "web-01", "3com.com", "a.b.c.", "xn--bcher-kva.example"},
+			[]string{"", "-web", "web-", "a..b", "under_score", "héllo", "a b", ".", strings.Repeat("a", 64)}},
+		{"fqdn", "fqdn.invalid",
+			[]string{"example.com", "www.example.com.", "a.b.io"},
+			[]string{"localhost", "10.0.0.1", "example.123", "example..com"}},
+		{"hostport", "hostport.invalid",
+			[]string{"example.com:443", "10.0.0.1:80", "[::1]:8080", "localhost:65535"},
+			[]string{"example.com", "::1:80", "example.com:0", "example.com:65536", "exa_mple.com:80", ":80"}},
+		{"port", "port.invalid",
+			[]string{"1", "80", "65535"},
+			[]string{"", "0", "65536", "080", "+80", "-1", "http"}},
+	} {
+		v := V[c.name]
+		for _, s := range c.ok {
+			Ω(v(s)).Should(BeNil(), "%s(%q)", c.name, s)
+		}
+		for _, s := range c.bad {
+			Ω(v(s)).Should(BeAssignableToTypeOf(validate.Error{}), "%s(%q)", c.name, s)
+			Ω(v(s).(validate.Error).Code).Should(Equal(c.code), "%s(%q)", c.name, s)
+		}
+	}
+}
+
+func TestNetworkValidatorsTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	type Device struct {
+		Addr    []byte   `validate:"ip"`
+		DNS     []string `validate:"ipv4"`
+		Gateway *string  `validate:"hostport"`
+	}
+	gw := "router.local:53"
+	Ω(V.Validate(Device{Addr: []byte("10.0.0.2"), DNS: []string{"1.1.1.1"}, Gateway: &gw})).Should(BeNil())
+
+	gw = "router.local"
+	errs := V.Validate(Device{Addr: []byte("10.0.0.256"), DNS: []string{"1.1.1.1", "::1"}, Gateway: &gw})
+	Ω(errs).Should(HaveLen(3))
+	Ω(errs["Addr"]).Should(MatchError("Should be an IP address"))
+	Ω(errs["DNS"]).Should(Equal([]interface{}{nil,
+		validate.NewError("ipv4.invalid", "Should be an IPv4 address", nil)}))
+	Ω(errs["Gateway"]).Should(Equal(hostportErr))
+
+	Ω(V["port"](8080)).Should(Equal(validate.NewError("string.type", "Should be a string or byte array", nil)))
+}
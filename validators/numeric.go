@@ -0,0 +1,273 @@
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PlanitarInc/validate"
+)
+
+var numTypeErr = validate.NewError("number.type", "Should be a number", nil)
+
+// toRat returns the exact value of a number of any kind: signed and unsigned
+// integers, floats, named numeric types, json.Number holding a valid JSON
+// number and the types of math/big, or pointers to any of those. A nil
+// pointer has no value and yields nil.
+//
+// Floats are taken by their shortest decimal representation, so that 0.1
+// compares equal to the literal 0.1 in a tag.
+func toRat(src interface{}) (*big.Rat, bool) {
+	switch n := src.(type) {
+	case json.Number:
+		return parseDecimal(string(n))
+	case *big.Int:
+		if n == nil {
+			return nil, true
+		}
+		return new(big.Rat).SetInt(n), true
+	case *big.Float:
+		if n == nil {
+			return nil, true
+		}
+		if n.IsInf() {
+			return nil, false
+		}
+		r, _ := n.Rat(nil)
+		return r, true
+	case *big.Rat:
+		if n == nil {
+			return nil, true
+		}
+		return new(big.Rat).Set(n), true
+	}
+
+	val := reflect.ValueOf(src)
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return nil, true
+		}
+		return toRat(val.Elem().Interface())
+	case reflect.Struct:
+		/* big.Int, big.Float and big.Rat held by value */
+		if !val.CanAddr() {
+			ptr := reflect.New(val.Type())
+			ptr.Elem().Set(val)
+			val = ptr.Elem()
+		}
+		switch val.Addr().Interface().(type) {
+		case *big.Int, *big.Float, *big.Rat:
+			return toRat(val.Addr().Interface())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(val.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, val.Type().Bits()))
+	}
+	return nil, false
+}
+
+// jsonNumberRE is the grammar of JSON numbers (RFC 8259). big.Rat's
+// SetString also takes fractions like "1/3" and prefixes like "0x10".
+var jsonNumberRE = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?$`)
+
+// parseDecimal parses s if it is a number as written in JSON.
+func parseDecimal(s string) (*big.Rat, bool) {
+	if !jsonNumberRE.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// parseNum parses a number given in a tag, which must be written as in
+// JSON. It also returns the number as written, for use in messages and as
+// a json.Number in params.
+func parseNum(s string) (*big.Rat, string, error) {
+	s = strings.TrimSpace(s)
+	r, ok := parseDecimal(s)
+	if !ok {
+		return nil, s, fmt.Errorf("invalid number %q", s)
+	}
+	return r, s, nil
+}
+
+// numValidator returns a validator that applies check to the value of
+// numbers of any kind; see toRat.
+func numValidator(check func(n *big.Rat) interface{}) validate.ValidatorFn {
+	return validate.Typed(func(src interface{}) interface{} {
+		n, ok := toRat(src)
+		if !ok {
+			return numTypeErr
+		}
+		if n == nil {
+			return nil
+		}
+		return check(n)
+	}, isNumType)
+}
+
+var numTypes = map[reflect.Type]bool{
+	reflect.TypeOf(json.Number("")): true,
+	reflect.TypeOf(big.Int{}):       true,
+	reflect.TypeOf(big.Float{}):     true,
+	reflect.TypeOf(big.Rat{}):       true,
+}
+
+// isNumType reports whether toRat takes values of type t.
+func isNumType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return numTypes[t]
+}
+
+func minErr(min string) validate.Error {
+	return validate.NewError("number.too_small",
+		"Minimum value is "+min, validate.Params{"min": json.Number(min)})
+}
+
+func maxErr(max string) validate.Error {
+	return validate.NewError("number.too_large",
+		"Maximum value is "+max, validate.Params{"max": json.Number(max)})
+}
+
+func minValidator(param string) (validate.ValidatorFn, error) {
+	min, text, err := parseNum(param)
+	if err != nil {
+		return nil, err
+	}
+	e := minErr(text)
+	return numValidator(func(n *big.Rat) interface{} {
+		if n.Cmp(min) < 0 {
+			return e
+		}
+		return nil
+	}), nil
+}
+
+func maxValidator(param string) (validate.ValidatorFn, error) {
+	max, text, err := parseNum(param)
+	if err != nil {
+		return nil, err
+	}
+	e := maxErr(text)
+	return numValidator(func(n *big.Rat) interface{} {
+		if n.Cmp(max) > 0 {
+			return e
+		}
+		return nil
+	}), nil
+}
+
+// rangeValidator takes the bounds as "min..max".
+func rangeValidator(param string) (validate.ValidatorFn, error) {
+	lo, hi, ok := strings.Cut(param, "..")
+	if !ok {
+		return nil, errors.New(`expected a range as "min..max"`)
+	}
+	min, loText, err := parseNum(lo)
+	if err != nil {
+		return nil, err
+	}
+	max, hiText, err := parseNum(hi)
+	if err != nil {
+		return nil, err
+	}
+	if min.Cmp(max) > 0 {
+		return nil, fmt.Errorf("empty range %s", param)
+	}
+	loErr, hiErr := minErr(loText), maxErr(hiText)
+	return numValidator(func(n *big.Rat) interface{} {
+		if n.Cmp(min) < 0 {
+			return loErr
+		}
+		if n.Cmp(max) > 0 {
+			return hiErr
+		}
+		return nil
+	}), nil
+}
+
+func multipleOfValidator(param string) (validate.ValidatorFn, error) {
+	m, text, err := parseNum(param)
+	if err != nil {
+		return nil, err
+	}
+	if m.Sign() == 0 {
+		return nil, errors.New("zero divisor")
+	}
+	e := validate.NewError("number.not_multiple",
+		"Should be a multiple of "+text, validate.Params{"multipleof": json.Number(text)})
+	return numValidator(func(n *big.Rat) interface{} {
+		if !new(big.Rat).Quo(n, m).IsInt() {
+			return e
+		}
+		return nil
+	}), nil
+}
+
+var positiveValidator = numValidator(func(n *big.Rat) interface{} {
+	if n.Sign() <= 0 {
+		return validate.NewError("number.not_positive", "Should be positive", nil)
+	}
+	return nil
+})
+
+var nonnegativeValidator = numValidator(func(n *big.Rat) interface{} {
+	if n.Sign() < 0 {
+		return validate.NewError("number.negative", "Should be nonnegative", nil)
+	}
+	return nil
+})
+
+// must panics if a validator could not be built, like regexp.MustCompile.
+func must(fn validate.ValidatorFn, err error) validate.ValidatorFn {
+	if err != nil {
+		panic("validators: " + err.Error())
+	}
+	return fn
+}
+
+// Min returns a validator for numbers of any kind that are at least min.
+// Bounds are exact: large int64 values, floats and math/big types are
+// compared without rounding. Nil pointers are valid. It panics if min is
+// not a decimal number as written in JSON, like "10", "-0.5" or "1e3".
+func Min(min string) validate.ValidatorFn {
+	return must(minValidator(min))
+}
+
+// Max is like Min, but checks that numbers are at most max.
+func Max(max string) validate.ValidatorFn {
+	return must(maxValidator(max))
+}
+
+// Range is like Min, but checks that numbers are between min and max,
+// inclusive.
+func Range(min, max string) validate.ValidatorFn {
+	return must(rangeValidator(min + ".." + max))
+}
+
+// MultipleOf is like Min, but checks that numbers are an integer multiple
+// of m.
+func MultipleOf(m string) validate.ValidatorFn {
+	return must(multipleOfValidator(m))
+}
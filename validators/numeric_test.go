@@ -0,0 +1,112 @@
+package validators
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestNumericTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	type Cents int64
+	type Ratio float32
+
+	min := Min("10")
+	minErr := validate.NewError("number.too_small", "Minimum value is 10",
+		validate.Params{"min": json.Number("10")})
+
+	n := 12
+	var nilPtr *int
+	for _, ok := range []interface{}{
+		10, int8(11), int16(12), int32(13), int64(14),
+		uint(10), uint8(11), uint16(12), uint32(13), uint64(math.MaxUint64), uintptr(10),
+		float32(10), 10.5, Cents(100), Ratio(10.25), &n, nilPtr,
+		json.Number("10"), json.Number("1e3"),
+		big.NewInt(10), *big.NewInt(11), big.NewFloat(10.5), big.NewRat(21, 2),
+	} {
+		Ω(min(ok)).Should(BeNil(), "%T %v", ok, ok)
+	}
+
+	for _, bad := range []interface{}{
+		9, int8(-1), uint8(9), 9.99, float32(9.99), Cents(-5), json.Number("9.9"),
+		big.NewInt(-10), big.NewFloat(9.5), *big.NewFloat(9.5), big.NewRat(19, 2),
+	} {
+		Ω(min(bad)).Should(Equal(minErr), "%T %v", bad, bad)
+	}
+
+	for _, bad := range []interface{}{
+		nil, "10", []int{10}, struct{}{}, math.NaN(), math.Inf(1), json.Number("x"),
+		json.Number("100/3"), json.Number("0x10"), json.Number("+11"), json.Number("011"),
+		json.Number("11."), json.Number(".5e2"), json.Number(" 11"),
+	} {
+		Ω(min(bad)).Should(Equal(numTypeErr), "%T %v", bad, bad)
+	}
+}
+
+func TestNumericExact(t *testing.T) {
+	RegisterTestingT(t)
+
+	/* float64(1<<53 + 1) == float64(1<<53) */
+	Ω(Max("9007199254740992")(int64(1<<53 + 1))).Should(HaveOccurred())
+	Ω(Max("9007199254740992")(int64(1 << 53))).ShouldNot(HaveOccurred())
+	Ω(Min("9223372036854775807")(int64(math.MaxInt64))).ShouldNot(HaveOccurred())
+	Ω(Max("18446744073709551614")(uint64(math.MaxUint64))).Should(HaveOccurred())
+
+	Ω(Max("0.1")(0.1)).ShouldNot(HaveOccurred())
+	Ω(Max("0.1")(float32(0.1))).ShouldNot(HaveOccurred())
+	Ω(Max("0.1")(0.10000000000000002)).Should(HaveOccurred())
+
+	Ω(MultipleOf("0.1")(0.3)).ShouldNot(HaveOccurred())
+	Ω(MultipleOf("0.01")(19.99)).ShouldNot(HaveOccurred())
+	Ω(MultipleOf("0.01")(19.999)).Should(Equal(validate.NewError("number.not_multiple",
+		"Should be a multiple of 0.01", validate.Params{"multipleof": json.Number("0.01")})))
+	Ω(MultipleOf("7")(int64(math.MaxInt64))).ShouldNot(HaveOccurred())
+	Ω(MultipleOf("5")(int64(math.MaxInt64))).Should(HaveOccurred())
+}
+
+func TestNumericValidators(t *testing.T) {
+	RegisterTestingT(t)
+
+	type X struct {
+		Limit   uint     `validate:"range=1..100"`
+		Price   float64  `validate:"positive,multipleof=0.01"`
+		Balance *big.Int `validate:"min(-1000)"`
+		Count   int8     `validate:"nonnegative,max=10"`
+	}
+
+	Ω(V.Validate(X{Limit: 1, Price: 0.5, Balance: big.NewInt(-1000)})).Should(BeNil())
+	Ω(V.Validate(X{Limit: 100, Price: 19.99, Count: 10})).Should(BeNil())
+
+	errs := V.Validate(X{Limit: 101, Price: 0, Balance: big.NewInt(-1001), Count: -1})
+	Ω(errs).Should(HaveLen(4))
+	Ω(errs["Limit"]).Should(MatchError("Maximum value is 100"))
+	Ω(errs["Price"]).Should(MatchError("Should be positive"))
+	Ω(errs["Balance"]).Should(MatchError("Minimum value is -1000"))
+	Ω(errs["Count"]).Should(MatchError("Should be nonnegative"))
+
+	errs = V.Validate(X{Limit: 0, Price: 1.001, Count: 11})
+	Ω(errs["Limit"]).Should(MatchError("Minimum value is 1"))
+	Ω(errs["Price"]).Should(MatchError("Should be a multiple of 0.01"))
+	Ω(errs["Count"]).Should(MatchError("Maximum value is 10"))
+
+	Ω(V.Var("min=x", 1).(validate.Error).Code).Should(Equal("validator.params"))
+	Ω(V.Var("range=2..1", 1).(validate.Error).Code).Should(Equal("validator.params"))
+	Ω(V.Var("multipleof=0", 1).(validate.Error).Code).Should(Equal("validator.params"))
+	Ω(func() { Min("x") }).Should(Panic())
+
+	/* Bounds are written as JSON numbers, and kept as such in params */
+	for _, param := range []string{"1/3", "0x10", "0b1", "+1", "01", "1.", ".5", "1_000", "Inf"} {
+		Ω(V.Var("max="+param, 1).(validate.Error).Code).Should(Equal("validator.params"), param)
+	}
+	for _, param := range []string{"0", "-0.5", "1e3", "2.5E-1", " 10 "} {
+		Ω(V.Var("max="+param, -1)).Should(BeNil(), param)
+	}
+	e := V.Var("max=1E2", 101).(validate.Error)
+	_, err := json.Marshal(e.Params)
+	Ω(err).ShouldNot(HaveOccurred())
+}
@@ -0,0 +1,103 @@
+package validators
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/PlanitarInc/validate"
+)
+
+var (
+	textTypesMu sync.RWMutex
+	textTypes   = map[string]func(s string) error{}
+)
+
+func init() {
+	RegisterTextType[netip.Addr]("netip.Addr")
+	RegisterTextType[netip.AddrPort]("netip.AddrPort")
+	RegisterTextType[netip.Prefix]("netip.Prefix")
+	RegisterTextType[net.IP]("net.IP")
+	RegisterTextType[big.Int]("big.Int")
+	RegisterTextType[big.Float]("big.Float")
+	RegisterTextType[big.Rat]("big.Rat")
+	RegisterTextType[time.Time]("time.Time")
+	RegisterTextType[slog.Level]("slog.Level")
+}
+
+// RegisterTextType registers the type T, whose pointer implements
+// encoding.TextUnmarshaler, under name for the parses validator:
+//
+//	validators.RegisterTextType[Color]("color")
+//
+//	type X struct {
+//		Background string `validate:"parses=color"`
+//	}
+//
+// A few types of the standard library are registered by the package name
+// and the type name: netip.Addr, netip.AddrPort, netip.Prefix, net.IP,
+// big.Int, big.Float, big.Rat, time.Time and slog.Level.
+//
+// Types are meant to be registered at start-up; registering a name again
+// replaces the type, also for validators already built by Parses or tags.
+func RegisterTextType[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](name string) {
+	textTypesMu.Lock()
+	defer textTypesMu.Unlock()
+	textTypes[name] = func(s string) error {
+		var v T
+		return PT(&v).UnmarshalText([]byte(s))
+	}
+}
+
+// parsesValidator looks the type up on every call, so that tags may name
+// types registered after they were first used.
+func parsesValidator(name string) (validate.ValidatorFn, error) {
+	if name == "" {
+		return nil, errors.New("expected the name of a type, see RegisterTextType")
+	}
+	unknownErr := validate.NewError("validator.params",
+		fmt.Sprintf("invalid parameters for \"parses\": unknown type %q, see RegisterTextType", name),
+		validate.Params{"name": "parses"})
+
+	return validate.Strings(func(str string) interface{} {
+		textTypesMu.RLock()
+		unmarshal, ok := textTypes[name]
+		textTypesMu.RUnlock()
+		if !ok {
+			return unknownErr
+		}
+		if err := unmarshal(str); err != nil {
+			return validate.NewError("parses.invalid", err.Error(), validate.Params{"type": name})
+		}
+		return nil
+	}), nil
+}
+
+// Parses returns a validator that checks that strings, byte arrays and each
+// element of string arrays unmarshal into the type registered under name
+// with RegisterTextType. Failures have the code "parses.invalid" and the
+// message of the unmarshal error.
+//
+// The same validator is registered in V as parses, with the name of the
+// type as a parameter: `parses=netip.Prefix`. Tags naming a type that is
+// not registered fail with the code "validator.params".
+//
+// Parses panics if no type is registered under name.
+func Parses(name string) validate.ValidatorFn {
+	textTypesMu.RLock()
+	_, ok := textTypes[name]
+	textTypesMu.RUnlock()
+	if !ok {
+		panic(fmt.Sprintf("validators: unknown type %q, see RegisterTextType", name))
+	}
+	return must(parsesValidator(name))
+}
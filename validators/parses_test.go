@@ -0,0 +1,84 @@
+package validators
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+type testColor struct{ r, g, b byte }
+
+func (c *testColor) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "red":
+		*c = testColor{r: 255}
+	case "black":
+		*c = testColor{}
+	default:
+		return errors.New("unknown color " + string(text))
+	}
+	return nil
+}
+
+func TestParses(t *testing.T) {
+	RegisterTestingT(t)
+
+	addr := Parses("netip.Addr")
+	Ω(addr("192.0.2.1")).Should(BeNil())
+	Ω(addr([]byte("2001:db8::1"))).Should(BeNil())
+	e := addr("192.0.2.256")
+	Ω(e).Should(BeAssignableToTypeOf(validate.Error{}))
+	Ω(e.(validate.Error).Code).Should(Equal("parses.invalid"))
+	Ω(e.(validate.Error).Params).Should(Equal(validate.Params{"type": "netip.Addr"}))
+	Ω(e).Should(MatchError(ContainSubstring("192.0.2.256")))
+
+	Ω(Parses("netip.Prefix")("10.0.0.0/8")).Should(BeNil())
+	Ω(Parses("big.Int")("123456789012345678901234567890")).Should(BeNil())
+	Ω(Parses("big.Int")("1.5")).ShouldNot(BeNil())
+	Ω(Parses("time.Time")("2024-01-01T00:00:00Z")).Should(BeNil())
+	Ω(Parses("slog.Level")("WARN")).Should(BeNil())
+	Ω(Parses("slog.Level")("LOUD")).ShouldNot(BeNil())
+	Ω(Parses("net.IP")([]string{"::1", "x"})).Should(HaveLen(2))
+	Ω(func() { Parses("color") }).Should(Panic())
+
+	RegisterTextType[testColor]("test.color")
+	type X struct {
+		Fill   string   `validate:"parses=test.color"`
+		Stroke []string `validate:"parses=test.color"`
+		Mask   string   `validate:"parses=netip.Prefix"`
+	}
+	Ω(V.Validate(X{"red", []string{"black"}, "10.0.0.0/8"})).Should(BeNil())
+	errs := V.Validate(X{"blue", []string{"red", "pink"}, "10.0.0.0/33"})
+	Ω(errs).Should(HaveLen(3))
+	Ω(errs["Fill"]).Should(Equal(validate.NewError("parses.invalid", "unknown color blue",
+		validate.Params{"type": "test.color"})))
+	Ω(errs["Stroke"]).Should(Equal([]interface{}{nil, validate.NewError("parses.invalid",
+		"unknown color pink", validate.Params{"type": "test.color"})}))
+	Ω(V.Var("parses=test.nothing", "x").(validate.Error).Code).Should(Equal("validator.params"))
+}
+
+type testShade struct{}
+
+func (*testShade) UnmarshalText(text []byte) error {
+	if string(text) != "dark" {
+		return errors.New("unknown shade " + string(text))
+	}
+	return nil
+}
+
+func TestParsesLateRegistration(t *testing.T) {
+	RegisterTestingT(t)
+
+	/* Tags may be used before the type is registered, or replaced */
+	Ω(V.Var("parses=test.late", "red").(validate.Error).Code).Should(Equal("validator.params"))
+	RegisterTextType[testColor]("test.late")
+	Ω(V.Var("parses=test.late", "red")).Should(BeNil())
+
+	fn := Parses("test.late")
+	RegisterTextType[testShade]("test.late")
+	Ω(V.Var("parses=test.late", "dark")).Should(BeNil())
+	Ω(fn("red")).Should(Equal(validate.NewError("parses.invalid", "unknown shade red",
+		validate.Params{"type": "test.late"})))
+}
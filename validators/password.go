@@ -0,0 +1,219 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// PasswordPolicy is a set of requirements for passwords. Zero fields
+// impose no requirement:
+//
+//	policy := validators.PasswordPolicy{
+//		MinLength:    10,
+//		RequireUpper: true,
+//		RequireDigit: true,
+//		MaxRepeated:  3,
+//		UserFields:   []string{"username", "email"},
+//		Breached:     breached, // a *BloomFilter loaded at start-up
+//	}
+//	vd["password"] = policy.Validator()
+//
+// Failures are reported as a single Error with the code "password.invalid"
+// whose message lists the unmet requirements and whose "failed" parameter
+// holds their names: min_length, max_length, lower, upper, digit, symbol,
+// repeated, personal, entropy and breached.
+type PasswordPolicy struct {
+	// MinLength and MaxLength bound the number of characters (runes).
+	MinLength, MaxLength int
+	// RequireLower, RequireUpper, RequireDigit and RequireSymbol require a
+	// character of the class. Symbols are anything but letters and digits.
+	RequireLower, RequireUpper, RequireDigit, RequireSymbol bool
+	// MaxRepeated limits runs of the same character: with 2, "aa" passes and
+	// "aaa" does not.
+	MaxRepeated int
+	// UserFields names fields of the struct holding the password, e.g. the
+	// user name or the email, whose values must not appear in it. Names are
+	// matched against both the Go and the JSON names of the fields. Emails
+	// are also matched by their local part.
+	UserFields []string
+	// MinEntropy is the minimum estimated entropy in bits. The estimate is
+	// length * log2(size of the alphabets used), which overrates passwords
+	// made of words and patterns.
+	MinEntropy float64
+	// Breached, if set, rejects the passwords it contains.
+	Breached *BloomFilter
+}
+
+/* Values shorter than that are too likely to appear by chance */
+const minPersonalLen = 3
+
+func (p PasswordPolicy) requirements(pw string, personal []string) (failed, msgs []string) {
+	fail := func(name, msg string) {
+		failed = append(failed, name)
+		msgs = append(msgs, msg)
+	}
+
+	n := utf8.RuneCountInString(pw)
+	if p.MinLength > 0 && n < p.MinLength {
+		fail("min_length", fmt.Sprintf("be at least %d characters long", p.MinLength))
+	}
+	if p.MaxLength > 0 && n > p.MaxLength {
+		fail("max_length", fmt.Sprintf("be at most %d characters long", p.MaxLength))
+	}
+
+	var lower, upper, digit, symbol, other bool
+	run, maxRun, prev := 0, 0, rune(-1)
+	for _, r := range pw {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+			other = other || r >= utf8.RuneSelf
+		case unicode.IsUpper(r):
+			upper = true
+			other = other || r >= utf8.RuneSelf
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsLetter(r):
+			other = true
+		default:
+			symbol = true
+			other = other || r >= utf8.RuneSelf
+		}
+		if r == prev {
+			run++
+		} else {
+			run = 1
+		}
+		if run > maxRun {
+			maxRun = run
+		}
+		prev = r
+	}
+	if p.RequireLower && !lower {
+		fail("lower", "contain a lowercase letter")
+	}
+	if p.RequireUpper && !upper {
+		fail("upper", "contain an uppercase letter")
+	}
+	if p.RequireDigit && !digit {
+		fail("digit", "contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		fail("symbol", "contain a symbol")
+	}
+	if p.MaxRepeated > 0 && maxRun > p.MaxRepeated {
+		fail("repeated", fmt.Sprintf("not repeat a character more than %d times in a row", p.MaxRepeated))
+	}
+
+	lpw := strings.ToLower(pw)
+	for _, s := range personal {
+		if s = strings.ToLower(strings.TrimSpace(s)); len(s) >= minPersonalLen && strings.Contains(lpw, s) {
+			fail("personal", "not contain personal information")
+			break
+		}
+	}
+
+	if p.MinEntropy > 0 {
+		pool := 0
+		for _, c := range []struct {
+			used bool
+			size int
+		}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+			if c.used {
+				pool += c.size
+			}
+		}
+		if pool == 0 || float64(n)*math.Log2(float64(pool)) < p.MinEntropy {
+			fail("entropy", "be less predictable")
+		}
+	}
+
+	if p.Breached.Contains(pw) {
+		fail("breached", "not be a commonly used or breached password")
+	}
+	return failed, msgs
+}
+
+// Check checks password against the policy and returns an Error listing the
+// unmet requirements, or nil. personal are values that must not appear in
+// the password, like the user name.
+func (p PasswordPolicy) Check(password string, personal ...string) error {
+	failed, msgs := p.requirements(password, personal)
+	if len(failed) == 0 {
+		return nil
+	}
+	return validate.NewError("password.invalid",
+		"Password should "+strings.Join(msgs, ", "),
+		validate.Params{"failed": failed})
+}
+
+// personal returns the values of the user fields of parent, with emails
+// also split into their local parts.
+func (p PasswordPolicy) personal(parent interface{}) []string {
+	val := reflect.ValueOf(parent)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var values []string
+	for i := 0; i < val.NumField(); i++ {
+		sf := val.Type().Field(i)
+		jsonName, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		for _, name := range p.UserFields {
+			if name != sf.Name && name != jsonName {
+				continue
+			}
+			fv := val.Field(i)
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() != reflect.String {
+				continue
+			}
+			s := fv.String()
+			values = append(values, s)
+			if local, _, ok := strings.Cut(s, "@"); ok {
+				values = append(values, local)
+			}
+		}
+	}
+	return values
+}
+
+// Validator returns a validator for passwords held in string fields. With
+// UserFields, it only works through Validate and ValidateContext, which
+// give it access to the other fields.
+func (p PasswordPolicy) Validator() validate.ValidatorFn {
+	if len(p.UserFields) == 0 {
+		return validate.Rule[string](func(pw string) interface{} {
+			if err := p.Check(pw); err != nil {
+				return err
+			}
+			return nil
+		}).Fn()
+	}
+
+	return validate.WithContext(func(ctx context.Context, fc validate.FieldContext) error {
+		personal := p.personal(fc.Parent)
+		e := validate.Rule[string](func(pw string) interface{} {
+			if err := p.Check(pw, personal...); err != nil {
+				return err
+			}
+			return nil
+		}).Fn()(fc.Value)
+		if err, ok := e.(error); ok {
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,143 @@
+package validators
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func failedRequirements(err error) []string {
+	if err == nil {
+		return nil
+	}
+	return err.(validate.Error).Params["failed"].([]string)
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	p := PasswordPolicy{
+		MinLength:     8,
+		MaxLength:     16,
+		RequireLower:  true,
+		RequireUpper:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		MaxRepeated:   2,
+	}
+	Ω(p.Check("Tr0ub4dor&3")).Should(BeNil())
+	Ω(p.Check("pässwÖrd1!")).Should(BeNil())
+
+	err := p.Check("abc")
+	Ω(err).Should(Equal(validate.NewError("password.invalid",
+		"Password should be at least 8 characters long, contain an uppercase letter, "+
+			"contain a digit, contain a symbol",
+		validate.Params{"failed": []string{"min_length", "upper", "digit", "symbol"}})))
+
+	Ω(failedRequirements(p.Check(strings.Repeat("aB1!", 5)))).Should(Equal([]string{"max_length"}))
+	Ω(failedRequirements(p.Check("ABCDEFG1!"))).Should(Equal([]string{"lower"}))
+	Ω(failedRequirements(p.Check("aaaB1!xyz"))).Should(Equal([]string{"repeated"}))
+	Ω(p.Check("aaB1!xyz")).Should(BeNil())
+
+	Ω(PasswordPolicy{}.Check("")).Should(BeNil())
+}
+
+func TestPasswordPolicyEntropy(t *testing.T) {
+	RegisterTestingT(t)
+
+	p := PasswordPolicy{MinEntropy: 50}
+	Ω(failedRequirements(p.Check("12345678"))).Should(Equal([]string{"entropy"}))   /* 26.6 bits */
+	Ω(failedRequirements(p.Check("abcdefghij"))).Should(Equal([]string{"entropy"})) /* 47 bits */
+	Ω(p.Check("abcdefghijk")).Should(BeNil())                                       /* 51.7 bits */
+	Ω(p.Check("aB3$eF7*")).Should(BeNil())                                          /* 52.4 bits */
+	Ω(failedRequirements(p.Check(""))).Should(Equal([]string{"entropy"}))
+}
+
+func TestPasswordPolicyPersonal(t *testing.T) {
+	RegisterTestingT(t)
+
+	vd := validate.V{"password": PasswordPolicy{
+		MinLength:  8,
+		UserFields: []string{"username", "Email"},
+	}.Validator()}
+
+	type Signup struct {
+		Username string  `json:"username"`
+		Email    *string `json:"email"`
+		Password string  `json:"password" validate:"password"`
+	}
+	email := "jane.doe@example.com"
+
+	Ω(vd.Validate(Signup{"jdoe", &email, "correct horse"})).Should(BeNil())
+	Ω(vd.Validate(&Signup{"jdoe", nil, "correct horse"})).Should(BeNil())
+	Ω(vd.Validate(Signup{"jo", &email, "jo-jo-jo-jo"})).Should(BeNil())
+
+	personalErr := validate.NewError("password.invalid",
+		"Password should not contain personal information",
+		validate.Params{"failed": []string{"personal"}})
+	Ω(vd.Validate(Signup{"jdoe", &email, "i-am-JDOE-42"})).Should(Equal(
+		map[string]interface{}{"password": personalErr}))
+	Ω(vd.Validate(&Signup{"jdoe", &email, "Jane.Doe1234"})).Should(Equal(
+		map[string]interface{}{"password": personalErr}))
+
+	Ω(PasswordPolicy{}.Check("letmein-bob", "Bob")).Should(Equal(personalErr))
+}
+
+func TestPasswordPolicyBreached(t *testing.T) {
+	RegisterTestingT(t)
+
+	f := NewBloomFilter(3, 0.001)
+	n, err := f.AddLines(strings.NewReader("password\n 123456 \n\nqwerty\n"))
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(n).Should(Equal(3))
+
+	p := PasswordPolicy{Breached: f}
+	Ω(failedRequirements(p.Check("qwerty"))).Should(Equal([]string{"breached"}))
+	Ω(failedRequirements(p.Check("123456"))).Should(Equal([]string{"breached"}))
+	Ω(p.Check("correct horse battery staple")).Should(BeNil())
+
+	v := p.Validator()
+	Ω(v("password")).Should(MatchError("Password should not be a commonly used or breached password"))
+	Ω(v(1)).Should(Equal(validate.NewError("string.type", "Should be a string", nil)))
+}
+
+func TestBloomFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	f := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.Add("pw" + strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i++ {
+		Ω(f.Contains("pw" + strconv.Itoa(i))).Should(BeTrue())
+	}
+	fp := 0
+	for i := 0; i < 10000; i++ {
+		if f.Contains("other" + strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	Ω(fp).Should(BeNumerically("<", 300))
+
+	data, err := f.MarshalBinary()
+	Ω(err).ShouldNot(HaveOccurred())
+	var g BloomFilter
+	Ω(g.UnmarshalBinary(data)).Should(Succeed())
+	Ω(g).Should(Equal(*f))
+
+	Ω(g.UnmarshalBinary(data[:len(data)-1])).ShouldNot(Succeed())
+	Ω(g.UnmarshalBinary([]byte("nope"))).ShouldNot(Succeed())
+
+	/* A header-only filter whose size overflows when rounded up */
+	huge := append([]byte(nil), data[:len(data)-8*len(f.bits)]...)
+	binary.LittleEndian.PutUint64(huge[len(huge)-8:], math.MaxUint64)
+	Ω(g.UnmarshalBinary(huge)).ShouldNot(Succeed())
+
+	var empty *BloomFilter
+	Ω(empty.Contains("x")).Should(BeFalse())
+}
@@ -0,0 +1,260 @@
+package validators
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/PlanitarInc/validate"
+)
+
+var (
+	//go:embed data/phone.txt
+	phoneData string
+	//go:embed data/callingcodes.txt
+	callingCodeData string
+)
+
+// phoneRegion is the numbering plan of a region, see data/phone.txt.
+type phoneRegion struct {
+	name     string
+	code     string /* country calling code */
+	trunk    string /* national prefix, dialed before national numbers */
+	idd      string /* prefix for international calls */
+	national *regexp.Regexp
+}
+
+var (
+	phoneOnce    sync.Once
+	phoneRegions map[string]*phoneRegion
+	phoneCodes   map[string][]*phoneRegion
+	// phoneOther holds the country codes missing from the metadata, as
+	// regions without a name or national number pattern.
+	phoneOther map[string]*phoneRegion
+)
+
+func loadPhoneRegions() {
+	phoneRegions = map[string]*phoneRegion{}
+	phoneCodes = map[string][]*phoneRegion{}
+	sc := bufio.NewScanner(strings.NewReader(phoneData))
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) == 0 || strings.HasPrefix(f[0], "#") {
+			continue
+		}
+		r := &phoneRegion{
+			name:     f[0],
+			code:     f[1],
+			trunk:    strings.Trim(f[2], "-"),
+			idd:      f[3],
+			national: regexp.MustCompile(`^(?:` + f[4] + `)$`),
+		}
+		phoneRegions[r.name] = r
+		phoneCodes[r.code] = append(phoneCodes[r.code], r)
+	}
+
+	phoneOther = map[string]*phoneRegion{}
+	isoFields(callingCodeData, func(f []string) {
+		for _, code := range f {
+			if phoneCodes[code] == nil {
+				phoneOther[code] = &phoneRegion{code: code}
+			}
+		}
+	})
+}
+
+/* E.164 numbers have at most 15 digits, country code included */
+const maxPhoneDigits = 15
+
+/* The shortest national numbers, of some small islands, have 4 digits */
+const minPhoneNational = 4
+
+var phoneErr = validate.NewError("phone.invalid", "Should be a valid phone number", nil)
+
+type phoneNumber struct {
+	region   *phoneRegion
+	national string
+}
+
+func (n phoneNumber) e164() string {
+	return "+" + n.region.code + n.national
+}
+
+// phoneDigits strips the punctuation commonly used to format phone numbers
+// and reports whether the number was written with a leading '+'.
+func phoneDigits(s string) (digits string, plus bool, ok bool) {
+	s = strings.TrimSpace(s)
+	if plus = strings.HasPrefix(s, "+"); plus {
+		s = s[1:]
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			b.WriteByte(c)
+		case strings.IndexByte(" -./()", c) < 0:
+			return "", false, false
+		}
+	}
+	return b.String(), plus, b.Len() > 0
+}
+
+// matchNational finds the region of a national number among the regions
+// sharing a country code, allowing for a trunk prefix before the number.
+func matchNational(regions []*phoneRegion, national string) (phoneNumber, bool) {
+	for _, r := range regions {
+		if r.national.MatchString(national) {
+			return phoneNumber{r, national}, true
+		}
+	}
+	for _, r := range regions {
+		if n := strings.TrimPrefix(national, r.trunk); r.trunk != "" && n != national && r.national.MatchString(n) {
+			return phoneNumber{r, n}, true
+		}
+	}
+	return phoneNumber{}, false
+}
+
+// parsePhone parses a phone number in international format, or in the
+// national format of def if it is not nil.
+func parsePhone(s string, def *phoneRegion) (phoneNumber, bool) {
+	digits, plus, ok := phoneDigits(s)
+	if !ok {
+		return phoneNumber{}, false
+	}
+	if !plus && def != nil && strings.HasPrefix(digits, def.idd) {
+		digits, plus = digits[len(def.idd):], true
+	}
+
+	var n phoneNumber
+	switch {
+	case plus:
+		/* Country codes are prefix-free, at most one length matches */
+		ok = false
+		for l := 1; l <= 3 && l < len(digits) && !ok; l++ {
+			if regions := phoneCodes[digits[:l]]; regions != nil {
+				n, ok = matchNational(regions, digits[l:])
+			} else if r := phoneOther[digits[:l]]; r != nil {
+				n, ok = phoneNumber{r, digits[l:]}, len(digits)-l >= minPhoneNational
+			}
+		}
+	case def != nil:
+		n, ok = matchNational(phoneCodes[def.code], digits)
+	default:
+		return phoneNumber{}, false
+	}
+	if !ok || len(n.region.code)+len(n.national) > maxPhoneDigits {
+		return phoneNumber{}, false
+	}
+	return n, true
+}
+
+func lookupPhoneRegions(names []string) ([]*phoneRegion, error) {
+	phoneOnce.Do(loadPhoneRegions)
+	regions := make([]*phoneRegion, len(names))
+	for i, name := range names {
+		if regions[i] = phoneRegions[name]; regions[i] == nil {
+			return nil, fmt.Errorf("unknown phone region %q", name)
+		}
+	}
+	return regions, nil
+}
+
+func phone(names []string) (validate.ValidatorFn, error) {
+	regions, err := lookupPhoneRegions(names)
+	if err != nil {
+		return nil, err
+	}
+	var def *phoneRegion
+	if len(regions) > 0 {
+		def = regions[0]
+	}
+	regionErr := validate.NewError("phone.region",
+		"Phone number should be from: "+strings.Join(names, ", "),
+		validate.Params{"regions": names})
+
+	return validate.Strings(func(str string) interface{} {
+		n, ok := parsePhone(str, def)
+		if !ok {
+			return phoneErr
+		}
+		if len(regions) == 0 {
+			return nil
+		}
+		for _, r := range regions {
+			if n.region == r {
+				return nil
+			}
+		}
+		return regionErr
+	}), nil
+}
+
+func phoneValidator(param string) (validate.ValidatorFn, error) {
+	var names []string
+	if param != "" {
+		names = strings.Split(param, "|")
+	}
+	return phone(names)
+}
+
+// Phone returns a validator that checks that strings, byte arrays and each
+// element of string arrays are phone numbers of the regions (ISO 3166-1
+// alpha-2 codes like "US"), or of any known region if none are given.
+//
+// Numbers are accepted in international format, "+1 (415) 555-0123", and
+// when regions are given, in the national format of the first one,
+// "(415) 555-0123", or with its international call prefix. Spaces, dashes,
+// dots, slashes and parentheses are ignored. The number is checked against
+// the length and prefix rules of its region from the bundled metadata,
+// which covers about 40 regions and does not distinguish numbers that are
+// assigned from those that could be. Numbers of the other country codes
+// assigned by the ITU are only checked for their length, and do not belong
+// to any of the regions that may be given.
+//
+// The same validator is registered in V as phone, where regions are given
+// as a '|'-separated parameter: `phone=US|CA`. Combined with the e164
+// modifier, values are stored in E.164 format:
+//
+//	Phone string `validate:"e164=US,phone=US|CA"`
+//
+// Phone panics if a region is unknown.
+func Phone(regions ...string) validate.ValidatorFn {
+	return must(phone(regions))
+}
+
+func e164(name string) (validate.ValidatorFn, error) {
+	var def *phoneRegion
+	if name != "" {
+		regions, err := lookupPhoneRegions([]string{name})
+		if err != nil {
+			return nil, err
+		}
+		def = regions[0]
+	} else {
+		phoneOnce.Do(loadPhoneRegions)
+	}
+
+	return StrModifier(func(s string) string {
+		if n, ok := parsePhone(s, def); ok {
+			return n.e164()
+		}
+		return s
+	}), nil
+}
+
+// E164 returns a modifier that rewrites phone numbers in E.164 format,
+// "+14155550123". Numbers in national format are read as numbers of the
+// region, if any. Values that are not phone numbers are left as they are
+// for the validators that follow to report.
+//
+// The same modifier is registered in V as e164, with the optional region
+// as a parameter: `e164=US`.
+//
+// E164 panics if the region is unknown.
+func E164(region string) validate.ValidatorFn {
+	return must(e164(region))
+}
@@ -0,0 +1,111 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestPhone(t *testing.T) {
+	RegisterTestingT(t)
+
+	any := Phone()
+	for _, s := range []string{
+		"+1 (415) 555-0123",
+		"+14155550123",
+		"+1 204 555 0123",
+		"+44 20 7946 0958",
+		"+44 (0)20 7946 0958",
+		"+49 30 123456",
+		"+33 6 12 34 56 78",
+		"+39 06 1234 5678",
+		"+7 912 345-67-89",
+		"+86 138 0013 8000",
+		"+61 2 9876 5432",
+		"+972-3-123-4567",
+		/* Country codes missing from the metadata */
+		"+36 1 234 5678",
+		"+62 21 1234 5678",
+		"+63 2 8123 4567",
+		"+66 2 123 4567",
+		"+84 24 1234 5678",
+		"+880 2 1234 5678",
+		"+966 11 234 5678",
+		"+683 4002",
+	} {
+		Ω(any(s)).Should(BeNil(), s)
+	}
+	for _, s := range []string{
+		"",
+		"+",
+		"415 555 0123",    /* national without a region */
+		"+1 415 555 012",  /* too short */
+		"+1 115 555 0123", /* area codes do not start with 1 */
+		"+999 1234567",    /* unknown country code */
+		"+36 123",         /* too short */
+		"+880 1234 5678 9012 3",
+		"+44 20 7946 0958 ext 1",
+		"+1 415 555 0123 4567 89",
+		"+39 2 1234 5678",
+	} {
+		Ω(any(s)).Should(Equal(phoneErr), s)
+	}
+	Ω(any([]string{"+14155550123", "nope"})).Should(Equal([]interface{}{nil, phoneErr}))
+	Ω(any(42)).ShouldNot(BeNil())
+
+	us := Phone("US")
+	Ω(us("(415) 555-0123")).Should(BeNil())
+	Ω(us("1-415-555-0123")).Should(BeNil())
+	Ω(us("011 44 20 7946 0958")).ShouldNot(BeNil())
+	regionErr := validate.NewError("phone.region", "Phone number should be from: US",
+		validate.Params{"regions": []string{"US"}})
+	Ω(us("+44 20 7946 0958")).Should(Equal(regionErr))
+	Ω(us("+36 1 234 5678")).Should(Equal(regionErr))
+	Ω(us("(204) 555-0123")).Should(Equal(regionErr)) /* Canada */
+	Ω(Phone("US", "CA")("(204) 555-0123")).Should(BeNil())
+
+	gb := Phone("GB")
+	Ω(gb("020 7946 0958")).Should(BeNil())
+	Ω(gb("00 44 20 7946 0958")).Should(BeNil())
+	Ω(gb("+1 415 555 0123")).ShouldNot(BeNil())
+	Ω(Phone("IT")("06 1234 5678")).Should(BeNil())
+	Ω(Phone("RU")("8 (912) 345-67-89")).Should(BeNil())
+	Ω(Phone("KZ")("8 (912) 345-67-89")).ShouldNot(BeNil())
+
+	Ω(func() { Phone("XX") }).Should(Panic())
+	Ω(func() { E164("XX") }).Should(Panic())
+}
+
+func TestE164(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(E164("")("+44 (0)20 7946 0958")).Should(Equal(validate.Modified("+442079460958")))
+	Ω(E164("")("+36 1 234 5678")).Should(Equal(validate.Modified("+3612345678")))
+	Ω(E164("")("(415) 555-0123")).Should(Equal(validate.Modified("(415) 555-0123")))
+	Ω(E164("US")("(415) 555-0123")).Should(Equal(validate.Modified("+14155550123")))
+	Ω(E164("DE")([]string{"030 123456", "x"})).Should(Equal(validate.Modified([]string{"+4930123456", "x"})))
+
+	type Profile struct {
+		Phone  string   `validate:"e164=US,phone=US|CA"`
+		Phones []string `validate:"e164,phone"`
+		Fax    string   `validate:"phone=US"`
+	}
+	p := Profile{
+		Phone:  "(204) 555-0123",
+		Phones: []string{"+33 6 12 34 56 78", "+1.415.555.0123"},
+		Fax:    "415-555-0123",
+	}
+	Ω(V.Validate(&p)).Should(BeNil())
+	Ω(p).Should(Equal(Profile{
+		Phone:  "+12045550123",
+		Phones: []string{"+33612345678", "+14155550123"},
+		Fax:    "415-555-0123",
+	}))
+
+	errs := V.Validate(&Profile{Phone: "555-0123", Phones: []string{"+1 415 555 0123"}, Fax: "+33 6 12 34 56 78"})
+	Ω(errs).Should(HaveLen(2))
+	Ω(errs["Phone"]).Should(Equal(phoneErr))
+	Ω(errs["Fax"]).Should(MatchError("Phone number should be from: US"))
+	Ω(V.Var("phone=Mars", "+14155550123").(validate.Error).Code).Should(Equal("validator.params"))
+}
@@ -0,0 +1,86 @@
+package validators
+
+import (
+	"strings"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// gtinCheck reports whether the digits pass the GS1 check: the sum of the
+// digits weighted 1 and 3 alternately from the right, the check digit
+// being weighted 1, is a multiple of 10.
+func gtinCheck(digits string) bool {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// isbn10Check reports whether the characters pass the ISBN-10 check: the
+// sum of the digits weighted 10 down to 1 is a multiple of 11, where the
+// check digit may be X for 10.
+func isbn10Check(s string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		d := int(s[i] - '0')
+		if i == 9 && s[i] == 'X' {
+			d = 10
+		}
+		sum += (10 - i) * d
+	}
+	return sum%11 == 0
+}
+
+func isISBN10(s string) bool {
+	if len(s) != 10 || !isDigits(s[:9]) {
+		return false
+	}
+	return isDigits(s[9:]) || s[9] == 'X'
+}
+
+func isISBN13(s string) bool {
+	return len(s) == 13 && isDigits(s) && (strings.HasPrefix(s, "978") || strings.HasPrefix(s, "979"))
+}
+
+// productCode returns a validator for codes of a fixed format, possibly
+// written with spaces or dashes, reporting "<name>.invalid" if the format
+// does not match and "<name>.checksum" if the check digit does not.
+func productCode(name, what string, format func(string) bool, check func(string) bool) validate.ValidatorFn {
+	formatErr := validate.NewError(name+".invalid", "Should be "+what, nil)
+	checksumErr := validate.NewError(name+".checksum", "Invalid check digit", nil)
+
+	return validate.Strings(func(str string) interface{} {
+		code := stripSeparators(str)
+		if !format(code) {
+			return formatErr
+		}
+		if !check(code) {
+			return checksumErr
+		}
+		return nil
+	})
+}
+
+func gtinCode(name, what string, length int) validate.ValidatorFn {
+	return productCode(name, what, func(s string) bool {
+		return len(s) == length && isDigits(s)
+	}, gtinCheck)
+}
+
+var (
+	isbn10Validator = productCode("isbn10", "an ISBN-10", isISBN10, isbn10Check)
+	isbn13Validator = productCode("isbn13", "an ISBN-13", isISBN13, gtinCheck)
+	isbnValidator   = productCode("isbn", "an ISBN",
+		func(s string) bool { return isISBN10(s) || isISBN13(s) },
+		func(s string) bool { return len(s) == 10 && isbn10Check(s) || len(s) == 13 && gtinCheck(s) })
+
+	ean8Validator   = gtinCode("ean8", "an EAN-8", 8)
+	ean13Validator  = gtinCode("ean13", "an EAN-13", 13)
+	upcaValidator   = gtinCode("upca", "a UPC-A", 12)
+	gtin14Validator = gtinCode("gtin14", "a GTIN-14", 14)
+)
@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestISBN(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(isbn10Validator("0306406152")).Should(BeNil())
+	Ω(isbn10Validator("0-306-40615-2")).Should(BeNil())
+	Ω(isbn10Validator("080442957X")).Should(BeNil())
+	Ω(isbn10Validator("0306406153")).Should(Equal(validate.NewError("isbn10.checksum", "Invalid check digit", nil)))
+	for _, s := range []string{"", "030640615", "03064061522", "X306406152", "080442957x", "9780306406157"} {
+		Ω(isbn10Validator(s)).Should(Equal(validate.NewError("isbn10.invalid", "Should be an ISBN-10", nil)), s)
+	}
+
+	Ω(isbn13Validator("9780306406157")).Should(BeNil())
+	Ω(isbn13Validator("978-3-16-148410-0")).Should(BeNil())
+	Ω(isbn13Validator("9780306406158")).Should(Equal(validate.NewError("isbn13.checksum", "Invalid check digit", nil)))
+	Ω(isbn13Validator("4006381333931")).Should(Equal(validate.NewError("isbn13.invalid", "Should be an ISBN-13", nil)))
+
+	Ω(isbnValidator([]string{"0306406152", "978 0 306 40615 7", "0306406153", "x"})).Should(Equal([]interface{}{
+		nil,
+		nil,
+		validate.NewError("isbn.checksum", "Invalid check digit", nil),
+		validate.NewError("isbn.invalid", "Should be an ISBN", nil),
+	}))
+	Ω(isbnValidator(9780306406157)).ShouldNot(BeNil())
+}
+
+func TestGTIN(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(ean8Validator("96385074")).Should(BeNil())
+	Ω(ean8Validator("96385075")).Should(Equal(validate.NewError("ean8.checksum", "Invalid check digit", nil)))
+	Ω(ean8Validator("9638507")).Should(Equal(validate.NewError("ean8.invalid", "Should be an EAN-8", nil)))
+
+	Ω(ean13Validator("4006381333931")).Should(BeNil())
+	Ω(ean13Validator("9780306406157")).Should(BeNil())
+	Ω(ean13Validator("4006381333932")).ShouldNot(BeNil())
+	Ω(ean13Validator("400638133393a")).Should(Equal(validate.NewError("ean13.invalid", "Should be an EAN-13", nil)))
+
+	Ω(upcaValidator("036000291452")).Should(BeNil())
+	Ω(upcaValidator("0 36000 29145 2")).Should(BeNil())
+	Ω(upcaValidator("036000291453")).ShouldNot(BeNil())
+	Ω(upcaValidator("4006381333931")).Should(Equal(validate.NewError("upca.invalid", "Should be a UPC-A", nil)))
+
+	Ω(gtin14Validator("10012345678902")).Should(BeNil())
+	Ω(gtin14Validator("10012345678903")).ShouldNot(BeNil())
+	Ω(gtin14Validator([]byte("00012345678905"))).Should(BeNil())
+
+	type Product struct {
+		ISBN    string   `validate:"isbn"`
+		Barcode []string `validate:"ean13"`
+	}
+	Ω(V.Validate(Product{"080442957X", []string{"4006381333931"}})).Should(BeNil())
+	errs := V.Validate(Product{"080442957X", []string{"4006381333931", "4006381333932"}})
+	Ω(errs).Should(Equal(map[string]interface{}{
+		"Barcode": []interface{}{nil, validate.NewError("ean13.checksum", "Invalid check digit", nil)},
+	}))
+}
@@ -0,0 +1,342 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// semver is a version as defined by Semantic Versioning 2.0.0. Build
+// metadata is not kept, as it does not take part in comparisons.
+type semver struct {
+	major, minor, patch uint64
+	pre                 []string
+}
+
+func isSemverIdent(s string, numeric bool) bool {
+	if s == "" {
+		return false
+	}
+	digits := true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if '0' <= c && c <= '9' {
+			continue
+		}
+		digits = false
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	/* Numeric identifiers have no leading zeros */
+	if digits && numeric && len(s) > 1 && s[0] == '0' {
+		return false
+	}
+	return true
+}
+
+func parseSemver(s string) (semver, bool) {
+	var v semver
+	s, build, hasBuild := strings.Cut(s, "+")
+	if hasBuild {
+		for _, id := range strings.Split(build, ".") {
+			if !isSemverIdent(id, false) {
+				return v, false
+			}
+		}
+	}
+	s, pre, hasPre := strings.Cut(s, "-")
+	if hasPre {
+		v.pre = strings.Split(pre, ".")
+		for _, id := range v.pre {
+			if !isSemverIdent(id, true) {
+				return v, false
+			}
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	nums := []*uint64{&v.major, &v.minor, &v.patch}
+	for i, p := range parts {
+		if !isDigits(p) || len(p) > 1 && p[0] == '0' {
+			return v, false
+		}
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return v, false
+		}
+		*nums[i] = n
+	}
+	return v, true
+}
+
+func cmpUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compare orders versions by their precedence: numerically by major, minor
+// and patch, then with pre-releases before releases, their identifiers
+// compared in turn, numbers below names.
+func (v semver) compare(w semver) int {
+	if c := cmpUint(v.major, w.major); c != 0 {
+		return c
+	}
+	if c := cmpUint(v.minor, w.minor); c != 0 {
+		return c
+	}
+	if c := cmpUint(v.patch, w.patch); c != 0 {
+		return c
+	}
+	switch {
+	case len(v.pre) == 0 && len(w.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(w.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.pre) && i < len(w.pre); i++ {
+		a, b := v.pre[i], w.pre[i]
+		an, aErr := strconv.ParseUint(a, 10, 64)
+		bn, bErr := strconv.ParseUint(b, 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := cmpUint(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		case a != b:
+			return strings.Compare(a, b)
+		}
+	}
+	return cmpUint(uint64(len(v.pre)), uint64(len(w.pre)))
+}
+
+// semverBound is a comparison of a constraint: the operator is one of
+// = != < <= > >=.
+type semverBound struct {
+	op string
+	v  semver
+}
+
+func (b semverBound) allows(v semver) bool {
+	c := v.compare(b.v)
+	switch b.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// parseSemverBounds parses a comparison into the bounds it stands for;
+// caret and tilde ranges give two.
+func parseSemverBounds(s string) ([]semverBound, error) {
+	op := ""
+	for _, o := range []string{">=", "<=", "!=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(s, o) {
+			op = o
+			break
+		}
+	}
+	v, ok := parseSemver(s[len(op):])
+	if !ok {
+		return nil, fmt.Errorf("invalid version in %q", s)
+	}
+
+	switch op {
+	case "", "=":
+		return []semverBound{{"=", v}}, nil
+	case "^":
+		/* Changes that do not modify the left-most non-zero number */
+		upper := semver{major: v.major + 1}
+		switch {
+		case v.major == 0 && v.minor == 0:
+			upper = semver{patch: v.patch + 1}
+		case v.major == 0:
+			upper = semver{minor: v.minor + 1}
+		}
+		return []semverBound{{">=", v}, {"<", upper}}, nil
+	case "~":
+		return []semverBound{{">=", v}, {"<", semver{major: v.major, minor: v.minor + 1}}}, nil
+	}
+	return []semverBound{{op, v}}, nil
+}
+
+// parseSemverConstraint parses alternatives separated by "||", each made
+// of comparisons separated by spaces that must all hold.
+func parseSemverConstraint(s string) ([][]semverBound, error) {
+	var alts [][]semverBound
+	for _, alt := range strings.Split(s, "||") {
+		var bounds []semverBound
+		for _, cmp := range strings.Fields(alt) {
+			b, err := parseSemverBounds(cmp)
+			if err != nil {
+				return nil, err
+			}
+			bounds = append(bounds, b...)
+		}
+		if len(bounds) == 0 {
+			return nil, errors.New("empty constraint")
+		}
+		alts = append(alts, bounds)
+	}
+	return alts, nil
+}
+
+var semverErr = validate.NewError("semver.invalid", "Should be a semantic version, e.g. 1.2.3", nil)
+
+func semverValidator(constraint string) (validate.ValidatorFn, error) {
+	var alts [][]semverBound
+	if strings.TrimSpace(constraint) != "" {
+		var err error
+		if alts, err = parseSemverConstraint(constraint); err != nil {
+			return nil, err
+		}
+	}
+	constraintErr := validate.NewError("semver.constraint", "Version should satisfy "+constraint,
+		validate.Params{"constraint": constraint})
+
+	return validate.Strings(func(str string) interface{} {
+		v, ok := parseSemver(str)
+		if !ok {
+			return semverErr
+		}
+		if len(alts) == 0 {
+			return nil
+		}
+	alternatives:
+		for _, bounds := range alts {
+			for _, b := range bounds {
+				if !b.allows(v) {
+					continue alternatives
+				}
+			}
+			return nil
+		}
+		return constraintErr
+	}), nil
+}
+
+// Semver returns a validator that checks that strings, byte arrays and each
+// element of string arrays are versions as defined by Semantic Versioning
+// 2.0.0, pre-release and build metadata included, and if a constraint is
+// given, that they satisfy it.
+//
+// A constraint is made of alternatives separated by "||", each made of
+// comparisons separated by spaces that must all hold: "=1.2.3" (or just
+// "1.2.3"), "!=", "<", "<=", ">" and ">=", along with "^1.2.3" for versions
+// not changing the left-most non-zero number and "~1.2.3" for versions not
+// changing the minor number. Versions compare by precedence, so that
+// pre-releases of a version are lower than the version.
+//
+// The same validator is registered in V as semver, with the constraint as
+// an optional parameter: `semver(>=1.2.0 <2.0.0 || ^3.0.0)`.
+//
+// Semver panics if the constraint is invalid.
+func Semver(constraint string) validate.ValidatorFn {
+	return must(semverValidator(constraint))
+}
+
+// siblingString returns the value of the string field of parent named name,
+// by its Go or JSON name.
+func siblingString(parent interface{}, name string) (string, bool) {
+	val := reflect.ValueOf(parent)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return "", false
+	}
+	for i := 0; i < val.NumField(); i++ {
+		sf := val.Type().Field(i)
+		jsonName, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name != sf.Name && name != jsonName {
+			continue
+		}
+		fv := val.Field(i)
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.String {
+			return "", false
+		}
+		return fv.String(), true
+	}
+	return "", false
+}
+
+// semverCmpValidator returns the builder of the validators registered in V
+// as semvergt and semvergte, which check that versions held in string
+// fields are greater than (or equal to) the version held in another string
+// field of the same struct, named by its Go or JSON name:
+//
+//	type Manifest struct {
+//		Version       string `validate:"semver,semvergte=MinAppVersion"`
+//		MinAppVersion string `validate:"semver"`
+//	}
+//
+// They only work through Validate and ValidateContext.
+func semverCmpValidator(orEqual bool) func(field string) (validate.ValidatorFn, error) {
+	name, code, msg := "semvergt", "semver.not_greater", "Should be greater than "
+	if orEqual {
+		name, code, msg = "semvergte", "semver.less", "Should be greater than or equal to "
+	}
+
+	return func(field string) (validate.ValidatorFn, error) {
+		if field == "" {
+			return nil, errors.New("expected a field name")
+		}
+		return validate.WithContext(func(ctx context.Context, fc validate.FieldContext) error {
+			other, ok := siblingString(fc.Parent, field)
+			if !ok {
+				return validate.NewError("validator.params",
+					fmt.Sprintf("invalid parameters for %q: no string field %q to compare versions with", name, field),
+					validate.Params{"name": name})
+			}
+			/* A missing or invalid version is for its own validators to report */
+			bound, ok := parseSemver(other)
+
+			e := validate.Rule[string](func(str string) interface{} {
+				v, valid := parseSemver(str)
+				if !valid {
+					return semverErr
+				}
+				if c := v.compare(bound); ok && (c < 0 || c == 0 && !orEqual) {
+					return validate.NewError(code, msg+field,
+						validate.Params{"field": field, "version": other})
+				}
+				return nil
+			}).Fn()(fc.Value)
+			if err, isErr := e.(error); isErr {
+				return err
+			}
+			return nil
+		}), nil
+	}
+}
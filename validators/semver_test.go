@@ -0,0 +1,124 @@
+package validators
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestSemverParse(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, s := range []string{
+		"0.0.0", "1.2.3", "10.20.30", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-0.3.7",
+		"1.0.0-x.7.z.92", "1.0.0-alpha+001", "1.0.0+20130313144700", "1.0.0-beta+exp.sha.5114f85",
+		"1.0.0-alpha-a.b-c", "1.0.0+0.build.01",
+	} {
+		_, ok := parseSemver(s)
+		Ω(ok).Should(BeTrue(), s)
+	}
+	for _, s := range []string{
+		"", "1", "1.2", "1.2.3.4", "v1.2.3", "01.2.3", "1.02.3", "1.2.03", "1.2.3-",
+		"1.2.3-01", "1.2.3-alpha..1", "1.2.3+", "1.2.3+a..b", "1.2.3-al_pha", " 1.2.3", "-1.2.3",
+		"99999999999999999999.0.0",
+	} {
+		_, ok := parseSemver(s)
+		Ω(ok).Should(BeFalse(), s)
+	}
+
+	/* The precedence example of the specification */
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := parseSemver(ordered[i])
+			b, _ := parseSemver(ordered[j])
+			Ω(a.compare(b)).Should(Equal(cmpUint(uint64(i), uint64(j))), ordered[i]+" vs "+ordered[j])
+		}
+	}
+	a, _ := parseSemver("1.0.0+build.1")
+	b, _ := parseSemver("1.0.0+build.2")
+	Ω(a.compare(b)).Should(Equal(0))
+}
+
+func TestSemver(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(Semver("")("1.2.3-rc.1+build")).Should(BeNil())
+	Ω(Semver("")("1.2")).Should(Equal(semverErr))
+	Ω(Semver("")([]string{"1.2.3", "x"})).Should(Equal([]interface{}{nil, semverErr}))
+
+	rangeErr := validate.NewError("semver.constraint", "Version should satisfy >=1.2.0 <2.0.0",
+		validate.Params{"constraint": ">=1.2.0 <2.0.0"})
+	r := Semver(">=1.2.0 <2.0.0")
+	Ω(r("1.2.0")).Should(BeNil())
+	Ω(r("1.9.9")).Should(BeNil())
+	Ω(r("1.1.9")).Should(Equal(rangeErr))
+	Ω(r("2.0.0")).Should(Equal(rangeErr))
+	Ω(r("2.0.0-rc.1")).Should(BeNil())
+	Ω(r("1.2.0-rc.1")).Should(Equal(rangeErr))
+
+	for constraint, cases := range map[string]map[string]bool{
+		"^1.2.3":           {"1.2.3": true, "1.9.0": true, "2.0.0": false, "1.2.2": false},
+		"^0.2.3":           {"0.2.9": true, "0.3.0": false},
+		"^0.0.3":           {"0.0.3": true, "0.0.4": false},
+		"~1.2.3":           {"1.2.9": true, "1.3.0": false},
+		"1.2.3":            {"1.2.3": true, "1.2.3+b": true, "1.2.4": false},
+		"!=1.2.3":          {"1.2.3": false, "1.2.4": true},
+		"<1.0.0 || >2.0.0": {"0.9.0": true, "1.5.0": false, "2.0.1": true},
+		">1.0.0 <=1.1.0":   {"1.0.0": false, "1.1.0": true},
+	} {
+		fn := Semver(constraint)
+		for v, ok := range cases {
+			if ok {
+				Ω(fn(v)).Should(BeNil(), constraint+" "+v)
+			} else {
+				Ω(fn(v)).ShouldNot(BeNil(), constraint+" "+v)
+			}
+		}
+	}
+
+	for _, c := range []string{">=1.2", "=>1.2.3", "1.2.3 ||", "^x"} {
+		Ω(func() { Semver(c) }).Should(Panic(), c)
+	}
+}
+
+func TestSemverTags(t *testing.T) {
+	RegisterTestingT(t)
+
+	type Manifest struct {
+		Version       string `json:"version" validate:"semver(>=1.0.0 <3.0.0),semvergt=minAppVersion"`
+		MinAppVersion string `json:"minAppVersion" validate:"semver"`
+		MaxAppVersion string `validate:"semver,semvergte=MinAppVersion"`
+	}
+	Ω(V.Validate(Manifest{"1.2.0", "1.0.0", "1.0.0"})).Should(BeNil())
+	Ω(V.Validate(&Manifest{"2.0.0-beta", "1.9.0", "2.0.0"})).Should(BeNil())
+
+	errs := V.Validate(Manifest{"1.0.0", "1.0.0", "0.9.0"})
+	Ω(errs).Should(Equal(map[string]interface{}{
+		"version": validate.NewError("semver.not_greater", "Should be greater than minAppVersion",
+			validate.Params{"field": "minAppVersion", "version": "1.0.0"}),
+		"MaxAppVersion": validate.NewError("semver.less", "Should be greater than or equal to MinAppVersion",
+			validate.Params{"field": "MinAppVersion", "version": "1.0.0"}),
+	}))
+
+	errs = V.Validate(Manifest{"3.1.0", "latest", "1.0.0"})
+	Ω(errs).Should(HaveLen(2))
+	Ω(errs["version"].(validate.Error).Code).Should(Equal("semver.constraint"))
+	Ω(errs["minAppVersion"]).Should(Equal(semverErr))
+
+	type Broken struct {
+		Version string `validate:"semvergt=Missing"`
+	}
+	errs = V.Validate(Broken{"1.0.0"})
+	Ω(errs["Version"]).Should(Equal(validate.NewError("validator.params",
+		`invalid parameters for "semvergt": no string field "Missing" to compare versions with`,
+		validate.Params{"name": "semvergt"})))
+	Ω(errors.Is(validate.Errors(errs), validate.ErrInvalidValue)).Should(BeFalse())
+	Ω(V.Var("semver=>=1.2", "1.2.0").(validate.Error).Code).Should(Equal("validator.params"))
+	Ω(V.Var("semver=>=1.2.0", "1.2.0")).Should(BeNil())
+}
@@ -0,0 +1,886 @@
+package validators
+
+import (
+	"bufio"
+	"compress/gzip"
+	"embed"
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PlanitarInc/validate"
+)
+
+// Password strength estimation after zxcvbn (Wheeler, "zxcvbn: Low-Budget
+// Password Strength Estimation", USENIX Security 2016): the password is
+// matched against dictionaries, keyboard patterns, repeats, sequences and
+// dates, and the cheapest way to guess it as a sequence of such matches
+// and brute-forced characters decides its score.
+//
+// The dictionaries are the frequency lists of zxcvbn, most common first:
+// common passwords, English words, and US surnames and first names merged
+// by rank. They are Copyright (c) 2012-2016 Dan Wheeler and Dropbox, Inc.,
+// and distributed under the MIT License.
+
+//go:embed data/passwords.txt.gz data/english.txt.gz data/names.txt.gz
+var strengthData embed.FS
+
+// Strength is the result of PasswordStrength.
+type Strength struct {
+	// Score is 0 (too guessable) to 4 (very unguessable).
+	Score int
+	// Guesses is the estimated number of guesses needed.
+	Guesses float64
+	// Warning explains what makes the password weak, if anything.
+	Warning string
+	// Suggestions tell how to choose a stronger password.
+	Suggestions []string
+}
+
+/* Passwords are only scored up to that length; the rest is brute-forced */
+const maxStrengthLen = 100
+
+type strengthMatch struct {
+	pattern string
+	i, j    int /* rune indices of the token, inclusive */
+	token   string
+	guesses float64
+
+	dict     string
+	rank     int
+	reversed bool
+	l33t     bool
+	turns    int /* spatial */
+	baseLen  int /* repeat */
+	year     int /* date, year */
+}
+
+var (
+	dictOnce    sync.Once
+	rankedDicts map[string]map[string]int
+	/* Length in runes of the longest word of rankedDicts */
+	dictMaxLen int
+)
+
+func loadDicts() {
+	rankedDicts = map[string]map[string]int{}
+	for _, name := range []string{"passwords", "english", "names"} {
+		f, err := strengthData.Open("data/" + name + ".txt.gz")
+		if err != nil {
+			panic(err)
+		}
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			panic(err)
+		}
+		ranks := map[string]int{}
+		sc := bufio.NewScanner(zr)
+		for sc.Scan() {
+			if w := strings.TrimSpace(sc.Text()); w != "" {
+				if _, ok := ranks[w]; !ok {
+					ranks[w] = len(ranks) + 1
+				}
+				if l := utf8.RuneCountInString(w); l > dictMaxLen {
+					dictMaxLen = l
+				}
+			}
+		}
+		if err := sc.Err(); err != nil {
+			panic(err)
+		}
+		f.Close()
+		rankedDicts[name] = ranks
+	}
+}
+
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'}, '8': {'b'}, '(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'},
+	'3': {'e'}, '6': {'g'}, '9': {'g'}, '1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'},
+	'7': {'l', 't'}, '0': {'o'}, '$': {'s'}, '5': {'s'}, '+': {'t'}, '%': {'x'}, '2': {'z'},
+}
+
+/* Keyboard layouts, as in zxcvbn; the leading newline is part of the layout */
+const (
+	qwertyLayout = "\n" +
+		"`~ 1! 2@ 3# 4$ 5% 6^ 7& 8* 9( 0) -_ =+\n" +
+		"    qQ wW eE rR tT yY uU iI oO pP [{ ]} \\|\n" +
+		"     aA sS dD fF gG hH jJ kK lL ;: '\"\n" +
+		"      zZ xX cC vV bB nN mM ,< .> /?"
+	keypadLayout = "  / * -\n" +
+		"7 8 9 +\n" +
+		"4 5 6\n" +
+		"1 2 3\n" +
+		"  0 ."
+)
+
+type keyGraph struct {
+	adj    map[rune][]string /* neighbors by direction, "" for none */
+	starts float64
+	degree float64
+}
+
+func buildGraph(layout string, slanted bool) *keyGraph {
+	type pos struct{ x, y int }
+	keys := map[pos]string{}
+	xUnit := len(strings.Fields(layout)[0]) + 1
+	for y, line := range strings.Split(layout, "\n") {
+		slant := 0
+		if slanted {
+			slant = y - 1
+		}
+		for _, tok := range strings.Fields(line) {
+			keys[pos{(strings.Index(line, tok) - slant) / xUnit, y}] = tok
+		}
+	}
+
+	g := &keyGraph{adj: map[rune][]string{}}
+	var edges int
+	for p, tok := range keys {
+		var around []pos
+		if slanted {
+			around = []pos{{p.x - 1, p.y}, {p.x, p.y - 1}, {p.x + 1, p.y - 1},
+				{p.x + 1, p.y}, {p.x, p.y + 1}, {p.x - 1, p.y + 1}}
+		} else {
+			around = []pos{{p.x - 1, p.y}, {p.x - 1, p.y - 1}, {p.x, p.y - 1}, {p.x + 1, p.y - 1},
+				{p.x + 1, p.y}, {p.x + 1, p.y + 1}, {p.x, p.y + 1}, {p.x - 1, p.y + 1}}
+		}
+		adj := make([]string, len(around))
+		for i, a := range around {
+			if adj[i] = keys[a]; adj[i] != "" {
+				edges++
+			}
+		}
+		for _, r := range tok {
+			g.adj[r] = adj
+		}
+	}
+	g.starts = float64(len(g.adj))
+	g.degree = float64(edges) / float64(len(keys))
+	return g
+}
+
+var keyGraphs = map[string]*keyGraph{
+	"qwerty": buildGraph(qwertyLayout, true),
+	"keypad": buildGraph(keypadLayout, false),
+}
+
+const shiftedKeys = "~!@#$%^&*()_+QWERTYUIOP{}|ASDFGHJKL:\"ZXCVBNM<>?"
+
+func nCk(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	r := 1.0
+	for d := 1; d <= k; d++ {
+		r = r * float64(n-k+d) / float64(d)
+	}
+	return r
+}
+
+func caseVariations(token string) float64 {
+	var upper, lower int
+	for _, r := range token {
+		if unicode.IsUpper(r) {
+			upper++
+		} else if unicode.IsLower(r) {
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	runes := []rune(token)
+	rest := string(runes[1:])
+	switch {
+	case lower == 0,
+		unicode.IsUpper(runes[0]) && strings.ToLower(rest) == rest,
+		unicode.IsUpper(runes[len(runes)-1]) && upper == 1:
+		return 2
+	}
+	v := 0.0
+	for i := 1; i <= upper && i <= lower; i++ {
+		v += nCk(upper+lower, i)
+	}
+	return v
+}
+
+// matchDictionaries finds the words of the dictionaries in pw, also when
+// reversed or spelled with l33t substitutions. Only substrings of up to
+// maxLen runes, the length of the longest word, are looked up.
+func matchDictionaries(pw []rune, dicts map[string]map[string]int, maxLen int) []strengthMatch {
+	var matches []strengthMatch
+	lower := []rune(strings.ToLower(string(pw)))
+	if len(lower) != len(pw) {
+		return nil
+	}
+
+	lookup := func(i, j int, word string, reversed, l33t bool) {
+		for name, ranks := range dicts {
+			rank, ok := ranks[word]
+			if !ok {
+				continue
+			}
+			token := string(pw[i : j+1])
+			g := float64(rank) * caseVariations(token)
+			if reversed {
+				g *= 2
+			}
+			if l33t {
+				g *= l33tVariations(strings.ToLower(token), word)
+			}
+			matches = append(matches, strengthMatch{
+				pattern: "dictionary", i: i, j: j, token: token, guesses: g,
+				dict: name, rank: rank, reversed: reversed, l33t: l33t,
+			})
+		}
+	}
+
+	n := len(lower)
+	rev := make([]rune, n)
+	for i := range lower {
+		rev[n-1-i] = lower[i]
+	}
+	for i := 0; i < n; i++ {
+		/* Spellings of lower[i:j+1] with l33t characters replaced */
+		spellings, subbed := []string{""}, false
+		for j := i; j < n && j-i < maxLen; j++ {
+			lookup(i, j, string(lower[i:j+1]), false, false)
+			if j > i {
+				/* rev[i:j+1] is lower[n-1-j : n-i] read backwards */
+				lookup(n-1-j, n-1-i, string(rev[i:j+1]), true, false)
+			}
+			spellings = unl33t(spellings, lower[j])
+			if _, ok := l33tTable[lower[j]]; ok {
+				subbed = true
+			}
+			if subbed {
+				for _, w := range spellings {
+					lookup(i, j, w, false, true)
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// unl33t extends the spellings with r, or with each of the letters r may
+// stand for if it is a l33t character, up to a few dozen combinations.
+func unl33t(spellings []string, r rune) []string {
+	letters, ok := l33tTable[r]
+	if !ok {
+		for k := range spellings {
+			spellings[k] += string(r)
+		}
+		return spellings
+	}
+	var next []string
+	for _, o := range spellings {
+		for _, l := range letters {
+			if len(next) < 32 {
+				next = append(next, o+string(l))
+			}
+		}
+	}
+	return next
+}
+
+func l33tVariations(token, word string) float64 {
+	t, w := []rune(token), []rune(word)
+	subs := map[[2]rune]bool{}
+	for k := range t {
+		if t[k] != w[k] {
+			subs[[2]rune{t[k], w[k]}] = true
+		}
+	}
+	v := 1.0
+	for s := range subs {
+		var subbed, plain int
+		for k := range t {
+			if t[k] == s[0] {
+				subbed++
+			} else if t[k] == s[1] {
+				plain++
+			}
+		}
+		if subbed == 0 || plain == 0 {
+			v *= 2
+			continue
+		}
+		p := 0.0
+		for i := 1; i <= subbed && i <= plain; i++ {
+			p += nCk(subbed+plain, i)
+		}
+		v *= p
+	}
+	return v
+}
+
+func matchSpatial(pw []rune) []strengthMatch {
+	var matches []strengthMatch
+	for name, g := range keyGraphs {
+		for i := 0; i < len(pw)-1; {
+			j, turns, lastDir, shifted := i+1, 0, -1, 0
+			if name == "qwerty" && strings.ContainsRune(shiftedKeys, pw[i]) {
+				shifted = 1
+			}
+			for {
+				found := false
+				if j < len(pw) {
+					for dir, keys := range g.adj[pw[j-1]] {
+						idx := strings.IndexRune(keys, pw[j])
+						if keys == "" || idx < 0 {
+							continue
+						}
+						found = true
+						if idx > 0 {
+							shifted++
+						}
+						if dir != lastDir {
+							turns++
+							lastDir = dir
+						}
+						break
+					}
+				}
+				if found {
+					j++
+					continue
+				}
+				if j-i > 2 {
+					m := strengthMatch{pattern: "spatial", i: i, j: j - 1, token: string(pw[i:j]), turns: turns}
+					m.guesses = spatialGuesses(g, j-i, turns, shifted)
+					matches = append(matches, m)
+				}
+				i = j
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func spatialGuesses(g *keyGraph, length, turns, shifted int) float64 {
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= turns && j <= i-1; j++ {
+			guesses += nCk(i-1, j-1) * g.starts * math.Pow(g.degree, float64(j))
+		}
+	}
+	if shifted > 0 {
+		unshifted := length - shifted
+		if unshifted <= 0 {
+			guesses *= 2
+		} else {
+			v := 0.0
+			for i := 1; i <= shifted && i <= unshifted; i++ {
+				v += nCk(shifted+unshifted, i)
+			}
+			guesses *= v
+		}
+	}
+	return guesses
+}
+
+func matchRepeats(pw []rune, userInputs map[string]int, year int) []strengthMatch {
+	var matches []strengthMatch
+	for i := 0; i < len(pw)-1; {
+		bestSpan, bestBase, bestCount := 0, 0, 0
+		for b := 1; i+2*b <= len(pw); b++ {
+			count := 1
+			for i+(count+1)*b <= len(pw) && string(pw[i+count*b:i+(count+1)*b]) == string(pw[i:i+b]) {
+				count++
+			}
+			if count >= 2 && count*b > bestSpan {
+				bestSpan, bestBase, bestCount = count*b, b, count
+			}
+		}
+		if bestSpan == 0 {
+			i++
+			continue
+		}
+		baseGuesses, _ := estimate(pw[i:i+bestBase], userInputs, year)
+		matches = append(matches, strengthMatch{
+			pattern: "repeat", i: i, j: i + bestSpan - 1, token: string(pw[i : i+bestSpan]),
+			guesses: baseGuesses * float64(bestCount), baseLen: bestBase,
+		})
+		i += bestSpan
+	}
+	return matches
+}
+
+func matchSequences(pw []rune) []strengthMatch {
+	var matches []strengthMatch
+	add := func(i, j int, delta rune) {
+		if delta < 0 {
+			delta = -delta
+		} else if delta == 0 {
+			return
+		}
+		if delta > 5 || j-i < 1 || j-i == 1 && delta != 1 {
+			return
+		}
+		first := pw[i]
+		base := 26.0
+		switch {
+		case strings.ContainsRune("aAzZ019", first):
+			base = 4
+		case unicode.IsDigit(first):
+			base = 10
+		case unicode.IsUpper(first):
+			base = 52
+		}
+		if pw[j] < first {
+			base *= 2
+		}
+		matches = append(matches, strengthMatch{
+			pattern: "sequence", i: i, j: j, token: string(pw[i : j+1]),
+			guesses: base * float64(j-i+1),
+		})
+	}
+
+	if len(pw) < 2 {
+		return nil
+	}
+	i, last := 0, pw[1]-pw[0]
+	for k := 2; k < len(pw); k++ {
+		if d := pw[k] - pw[k-1]; d != last {
+			add(i, k-1, last)
+			i, last = k-1, d
+		}
+	}
+	add(i, len(pw)-1, last)
+	return matches
+}
+
+var (
+	yearRE    = regexp.MustCompile(`19\d\d|20\d\d`)
+	sepDateRE = regexp.MustCompile(`^(\d{1,4})([\s/\\_.-])(\d{1,2})([\s/\\_.-])(\d{1,4})$`)
+
+	dateSplits = map[int][][2]int{
+		4: {{1, 2}, {2, 3}},
+		5: {{1, 3}, {2, 3}},
+		6: {{1, 2}, {2, 4}, {4, 5}},
+		7: {{1, 3}, {2, 3}, {4, 5}, {4, 6}},
+		8: {{2, 4}, {4, 6}},
+	}
+)
+
+// yearSpace is the number of years to guess from, the current year
+// included, to find year.
+func yearSpace(year, current int) float64 {
+	return math.Max(math.Abs(float64(year-current)), 20)
+}
+
+// dateYear returns the year of the date written as the three numbers, if
+// they make a valid day, month and year in some order.
+func dateYear(n [3]int) (int, bool) {
+	if n[1] > 31 || n[1] <= 0 {
+		return 0, false
+	}
+	var over12, over31, under1 int
+	for _, v := range n {
+		if v > 99 && v < 1000 || v > 2050 {
+			return 0, false
+		}
+		if v > 31 {
+			over31++
+		}
+		if v > 12 {
+			over12++
+		}
+		if v <= 0 {
+			under1++
+		}
+	}
+	if over31 >= 2 || over12 == 3 || under1 >= 2 {
+		return 0, false
+	}
+
+	isDM := func(a, b int) bool {
+		return 1 <= a && a <= 31 && 1 <= b && b <= 12 || 1 <= b && b <= 31 && 1 <= a && a <= 12
+	}
+	splits := [][3]int{{n[2], n[0], n[1]}, {n[0], n[1], n[2]}}
+	for _, s := range splits {
+		if 1000 <= s[0] && s[0] <= 2050 {
+			if isDM(s[1], s[2]) {
+				return s[0], true
+			}
+			return 0, false
+		}
+	}
+	for _, s := range splits {
+		if isDM(s[1], s[2]) {
+			switch y := s[0]; {
+			case y > 99:
+				return y, true
+			case y > 50:
+				return 1900 + y, true
+			default:
+				return 2000 + y, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func matchDates(pw []rune, year int) []strengthMatch {
+	var matches []strengthMatch
+	s := string(pw)
+	if len(s) != len(pw) {
+		return nil /* dates are ASCII, indices must be rune indices */
+	}
+
+	for _, loc := range yearRE.FindAllStringIndex(s, -1) {
+		y, _ := strconv.Atoi(s[loc[0]:loc[1]])
+		matches = append(matches, strengthMatch{
+			pattern: "year", i: loc[0], j: loc[1] - 1, token: s[loc[0]:loc[1]],
+			guesses: yearSpace(y, year), year: y,
+		})
+	}
+
+	var dates []strengthMatch
+	for i := 0; i < len(s); i++ {
+		for j := i + 3; j < len(s) && j < i+10; j++ {
+			tok := s[i : j+1]
+			best, found := 0, false
+			sep := false
+			if splits, ok := dateSplits[len(tok)]; ok && strings.Trim(tok, "0123456789") == "" {
+				for _, sp := range splits {
+					a, _ := strconv.Atoi(tok[:sp[0]])
+					b, _ := strconv.Atoi(tok[sp[0]:sp[1]])
+					c, _ := strconv.Atoi(tok[sp[1]:])
+					if y, ok := dateYear([3]int{a, b, c}); ok && (!found || yearSpace(y, year) < yearSpace(best, year)) {
+						best, found = y, true
+					}
+				}
+			} else if m := sepDateRE.FindStringSubmatch(tok); len(tok) >= 6 && m != nil && m[2] == m[4] {
+				a, _ := strconv.Atoi(m[1])
+				b, _ := strconv.Atoi(m[3])
+				c, _ := strconv.Atoi(m[5])
+				best, found = dateYear([3]int{a, b, c})
+				sep = true
+			}
+			if !found {
+				continue
+			}
+			g := yearSpace(best, year) * 365
+			if sep {
+				g *= 4
+			}
+			dates = append(dates, strengthMatch{pattern: "date", i: i, j: j, token: tok, guesses: g, year: best})
+		}
+	}
+
+	/* Drop dates contained in longer ones */
+	for _, d := range dates {
+		inner := false
+		for _, o := range dates {
+			if o != d && o.i <= d.i && o.j >= d.j {
+				inner = true
+				break
+			}
+		}
+		if !inner {
+			matches = append(matches, d)
+		}
+	}
+	return matches
+}
+
+// estimate finds the sequence of matches and brute-forced runs of pw that
+// needs the fewest guesses, as zxcvbn's most_guessable_match_sequence.
+// Dates are guessed from year, the current year.
+func estimate(pw []rune, userInputs map[string]int, year int) (float64, []strengthMatch) {
+	n := len(pw)
+	if n == 0 {
+		return 1, nil
+	}
+
+	dicts, maxLen := rankedDicts, dictMaxLen
+	if len(userInputs) > 0 {
+		dicts = map[string]map[string]int{"user_inputs": userInputs}
+		for k, v := range rankedDicts {
+			dicts[k] = v
+		}
+		for w := range userInputs {
+			if l := utf8.RuneCountInString(w); l > maxLen {
+				maxLen = l
+			}
+		}
+	}
+	var all []strengthMatch
+	all = append(all, matchDictionaries(pw, dicts, maxLen)...)
+	all = append(all, matchSpatial(pw)...)
+	all = append(all, matchRepeats(pw, userInputs, year)...)
+	all = append(all, matchSequences(pw)...)
+	all = append(all, matchDates(pw, year)...)
+
+	byEnd := make([][]strengthMatch, n)
+	for _, m := range all {
+		if m.j-m.i+1 < n {
+			min := 50.0
+			if m.i == m.j {
+				min = 10
+			}
+			m.guesses = math.Max(m.guesses, min)
+		}
+		byEnd[m.j] = append(byEnd[m.j], m)
+	}
+
+	type step struct {
+		m  strengthMatch
+		pi float64
+		g  float64
+	}
+	optimal := make([]map[int]step, n)
+	for k := range optimal {
+		optimal[k] = map[int]step{}
+	}
+	fact := func(l int) float64 {
+		f := 1.0
+		for i := 2; i <= l; i++ {
+			f *= float64(i)
+		}
+		return f
+	}
+	update := func(m strengthMatch, l int) {
+		k := m.j
+		pi := m.guesses
+		if l > 1 {
+			pi *= optimal[m.i-1][l-1].pi
+		}
+		g := fact(l)*pi + math.Pow(10000, float64(l-1))
+		for cl, s := range optimal[k] {
+			if cl <= l && s.g <= g {
+				return
+			}
+		}
+		optimal[k][l] = step{m, pi, g}
+	}
+	bruteforce := func(i, j int) strengthMatch {
+		g := math.Pow(10, float64(j-i+1))
+		min := 11.0
+		if j > i {
+			min = 51
+		}
+		return strengthMatch{pattern: "bruteforce", i: i, j: j, token: string(pw[i : j+1]), guesses: math.Max(g, min)}
+	}
+
+	for k := 0; k < n; k++ {
+		for _, m := range byEnd[k] {
+			if m.i > 0 {
+				for l := range optimal[m.i-1] {
+					update(m, l+1)
+				}
+			} else {
+				update(m, 1)
+			}
+		}
+		update(bruteforce(0, k), 1)
+		for i := 1; i <= k; i++ {
+			for l, s := range optimal[i-1] {
+				if s.m.pattern != "bruteforce" {
+					update(bruteforce(i, k), l+1)
+				}
+			}
+		}
+	}
+
+	bestL, bestG := 0, math.Inf(1)
+	for l, s := range optimal[n-1] {
+		if s.g < bestG || s.g == bestG && l < bestL {
+			bestL, bestG = l, s.g
+		}
+	}
+	seq := make([]strengthMatch, bestL)
+	for k, l := n-1, bestL; l > 0; l-- {
+		seq[l-1] = optimal[k][l].m
+		k = seq[l-1].i - 1
+	}
+	return bestG, seq
+}
+
+func strengthScore(guesses float64) int {
+	switch {
+	case guesses < 1e3+5:
+		return 0
+	case guesses < 1e6+5:
+		return 1
+	case guesses < 1e8+5:
+		return 2
+	case guesses < 1e10+5:
+		return 3
+	}
+	return 4
+}
+
+func strengthFeedback(score int, seq []strengthMatch) (string, []string) {
+	if len(seq) == 0 {
+		return "", []string{
+			"Use a few words, avoid common phrases",
+			"No need for symbols, digits, or uppercase letters",
+		}
+	}
+	if score > 2 {
+		return "", nil
+	}
+
+	longest := seq[0]
+	for _, m := range seq[1:] {
+		if len(m.token) > len(longest.token) {
+			longest = m
+		}
+	}
+	warning, suggestions := "", []string(nil)
+	sole := len(seq) == 1
+
+	switch longest.pattern {
+	case "dictionary":
+		switch longest.dict {
+		case "passwords":
+			switch {
+			case sole && !longest.l33t && !longest.reversed && longest.rank <= 10:
+				warning = "This is a top-10 common password"
+			case sole && !longest.l33t && !longest.reversed && longest.rank <= 100:
+				warning = "This is a top-100 common password"
+			case sole && !longest.l33t && !longest.reversed:
+				warning = "This is a very common password"
+			case longest.guesses <= 1e4:
+				warning = "This is similar to a commonly used password"
+			}
+		case "english":
+			if sole {
+				warning = "A word by itself is easy to guess"
+			}
+		case "names":
+			if sole {
+				warning = "Names and surnames by themselves are easy to guess"
+			} else {
+				warning = "Common names and surnames are easy to guess"
+			}
+		case "user_inputs":
+			warning = "Avoid using personal information"
+		}
+		token := []rune(longest.token)
+		switch {
+		case strings.ToUpper(longest.token) == longest.token && strings.ToLower(longest.token) != longest.token:
+			suggestions = append(suggestions, "All-uppercase is almost as easy to guess as all-lowercase")
+		case unicode.IsUpper(token[0]):
+			suggestions = append(suggestions, "Capitalization doesn't help very much")
+		}
+		if longest.reversed && len(token) >= 4 {
+			suggestions = append(suggestions, "Reversed words aren't much harder to guess")
+		}
+		if longest.l33t {
+			suggestions = append(suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much")
+		}
+	case "spatial":
+		warning = "Short keyboard patterns are easy to guess"
+		if longest.turns == 1 {
+			warning = "Straight rows of keys are easy to guess"
+		}
+		suggestions = append(suggestions, "Use a longer keyboard pattern with more turns")
+	case "repeat":
+		warning = `Repeats like "abcabcabc" are only slightly harder to guess than "abc"`
+		if longest.baseLen == 1 {
+			warning = `Repeats like "aaa" are easy to guess`
+		}
+		suggestions = append(suggestions, "Avoid repeated words and characters")
+	case "sequence":
+		warning = "Sequences like abc or 6543 are easy to guess"
+		suggestions = append(suggestions, "Avoid sequences")
+	case "year":
+		warning = "Recent years are easy to guess"
+		suggestions = append(suggestions, "Avoid recent years", "Avoid years that are associated with you")
+	case "date":
+		warning = "Dates are often easy to guess"
+		suggestions = append(suggestions, "Avoid dates and years that are associated with you")
+	}
+	return warning, append([]string{"Add another word or two. Uncommon words are better."}, suggestions...)
+}
+
+// PasswordStrength estimates how hard password is to guess. userInputs are
+// words an attacker may know, like the user's name or email, that are
+// matched like the bundled dictionaries of common passwords, English words
+// and names.
+//
+// Only the first 100 characters are scored; longer passwords are very
+// unguessable anyway.
+func PasswordStrength(password string, userInputs ...string) Strength {
+	return passwordStrength(time.Now(), password, userInputs)
+}
+
+func passwordStrength(now time.Time, password string, userInputs []string) Strength {
+	dictOnce.Do(loadDicts)
+
+	inputs := map[string]int{}
+	for _, in := range userInputs {
+		for _, w := range strings.FieldsFunc(strings.ToLower(in), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if _, ok := inputs[w]; !ok {
+				inputs[w] = len(inputs) + 1
+			}
+		}
+		local, _, _ := strings.Cut(in, "@")
+		for _, w := range []string{strings.ToLower(in), strings.ToLower(local)} {
+			if _, ok := inputs[w]; !ok && w != "" {
+				inputs[w] = len(inputs) + 1
+			}
+		}
+	}
+
+	pw := []rune(password)
+	if len(pw) > maxStrengthLen {
+		pw = pw[:maxStrengthLen]
+	}
+	guesses, seq := estimate(pw, inputs, now.Year())
+	s := Strength{Guesses: guesses, Score: strengthScore(guesses)}
+	s.Warning, s.Suggestions = strengthFeedback(s.Score, seq)
+	return s
+}
+
+func strengthValidator(now func() time.Time) func(param string) (validate.ValidatorFn, error) {
+	return func(param string) (validate.ValidatorFn, error) {
+		min := 3
+		if param != "" {
+			var err error
+			if min, err = strconv.Atoi(strings.TrimSpace(param)); err != nil || min < 0 || min > 4 {
+				return nil, errors.New("expected a score from 0 to 4")
+			}
+		}
+		return strongPassword(min, now), nil
+	}
+}
+
+// StrongPassword returns a validator that checks that strings, byte arrays
+// and each element of string arrays score at least min (0 to 4) by
+// PasswordStrength. Failures have the code "password.weak" and carry the
+// score, the warning and the suggestions of PasswordStrength as parameters.
+//
+// The validator is registered in V as passwordstrength, with the minimum
+// score as a parameter: `passwordstrength=3`. The default is 3.
+func StrongPassword(min int) validate.ValidatorFn {
+	return strongPassword(min, time.Now)
+}
+
+func strongPassword(min int, now func() time.Time) validate.ValidatorFn {
+	return validate.Strings(func(pw string) interface{} {
+		s := passwordStrength(now(), pw, nil)
+		if s.Score >= min {
+			return nil
+		}
+		return validate.NewError("password.weak", "Password is too easy to guess", validate.Params{
+			"score":       s.Score,
+			"min":         min,
+			"warning":     s.Warning,
+			"suggestions": s.Suggestions,
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+func TestPasswordStrength(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, pw := range []string{
+		"", "password", "Password1", "P@ssw0rd", "drowssap", "qwerty",
+		"qwertyuiop", "asdfghjkl;", "aaaaaaaa", "abcabcabc", "abcdefg",
+		"13579", "1990", "19901231", "12/31/1990", "january", "Sunflower1",
+	} {
+		Ω(PasswordStrength(pw).Score).Should(BeNumerically("<", 3), pw)
+	}
+	for _, pw := range []string{
+		"correcthorsebatterystaple", "Tr0ub4dor&3", "kx8#Lp2@vQ!9",
+		strings.Repeat("kx8#Lp2@vQ!9", 20),
+	} {
+		Ω(PasswordStrength(pw).Score).Should(BeNumerically(">=", 3), pw)
+	}
+
+	s := PasswordStrength("password")
+	Ω(s.Score).Should(Equal(0))
+	Ω(s.Warning).Should(Equal("This is a top-10 common password"))
+	Ω(s.Suggestions).ShouldNot(BeEmpty())
+
+	Ω(PasswordStrength("P@ssw0rd").Suggestions).Should(ContainElement(
+		"Predictable substitutions like '@' instead of 'a' don't help very much"))
+	Ω(PasswordStrength("qwertyuiop").Guesses).Should(BeNumerically("<", 1e4))
+	Ω(PasswordStrength("january").Warning).Should(Equal("This is a very common password"))
+	Ω(PasswordStrength("sunflower").Warning).Should(Equal("A word by itself is easy to guess"))
+	Ω(PasswordStrength("asdfghjkl;").Warning).Should(Equal("Straight rows of keys are easy to guess"))
+	Ω(PasswordStrength("aaaaaaaa").Warning).Should(Equal(`Repeats like "aaa" are easy to guess`))
+	Ω(PasswordStrength("abcdefg").Warning).Should(Equal("Sequences like abc or 6543 are easy to guess"))
+	Ω(PasswordStrength("12/31/1990").Warning).Should(Equal("Dates are often easy to guess"))
+
+	Ω(PasswordStrength("strong").Score).Should(BeNumerically(">", 0))
+	Ω(PasswordStrength("xyzzyplugh").Score).Should(BeNumerically(">=", 3))
+	s = PasswordStrength("xyzzyplugh", "Xyzzy Plugh", "xyzzy@example.com")
+	Ω(s.Score).Should(BeNumerically("<", 3))
+	Ω(s.Warning).Should(Equal("Avoid using personal information"))
+
+	s = PasswordStrength("correcthorsebatterystaple")
+	Ω(s.Score).Should(Equal(4))
+	Ω(s.Warning).Should(BeEmpty())
+	Ω(s.Suggestions).Should(BeEmpty())
+}
+
+func TestPasswordStrengthClock(t *testing.T) {
+	RegisterTestingT(t)
+
+	/* Years are guessed from the current one */
+	at := func(year int) float64 {
+		return passwordStrength(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), "1950", nil).Guesses
+	}
+	Ω(at(1950)).Should(BeNumerically("<", at(2050)))
+
+	then := WithClock(V, func() time.Time { return time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC) })
+	Ω(then.Var("passwordstrength=1", "1950")).ShouldNot(BeNil())
+	Ω(then.Var("passwordstrength", "correcthorsebatterystaple")).Should(BeNil())
+}
+
+func TestStrongPassword(t *testing.T) {
+	RegisterTestingT(t)
+
+	fn := StrongPassword(3)
+	Ω(fn("correcthorsebatterystaple")).Should(BeNil())
+	Ω(fn([]byte("correcthorsebatterystaple"))).Should(BeNil())
+	Ω(fn(42)).ShouldNot(BeNil())
+
+	e := fn("Password1")
+	Ω(e).Should(BeAssignableToTypeOf(validate.Error{}))
+	err := e.(validate.Error)
+	Ω(err.Code).Should(Equal("password.weak"))
+	Ω(err.Params["score"]).Should(BeNumerically("<", 3))
+	Ω(err.Params["min"]).Should(Equal(3))
+	Ω(err.Params["warning"]).Should(Equal("This is a very common password"))
+	Ω(StrongPassword(0)("Password1")).Should(BeNil())
+
+	type T struct {
+		Password string `validate:"passwordstrength(3)"`
+		PIN      string `validate:"passwordstrength=1"`
+		Any      string `validate:"passwordstrength"`
+	}
+	Ω(V.Validate(T{"correcthorsebatterystaple", "58203971", "correcthorsebatterystaple"})).Should(BeNil())
+	errs := V.Validate(T{"Password1", "1234", "qwerty"})
+	Ω(errs).Should(HaveKey("Password"))
+	Ω(errs).Should(HaveKey("PIN"))
+	Ω(errs).Should(HaveKey("Any"))
+
+	Ω(V.Var("passwordstrength=5", "x").(validate.Error).Code).Should(Equal("validator.params"))
+	Ω(V.Var("passwordstrength=x", "x").(validate.Error).Code).Should(Equal("validator.params"))
+}
+
+/* Inputs that make every substring a candidate for the dictionaries */
+var adversarialPasswords = []string{
+	strings.Repeat("1", 100),
+	strings.Repeat("ab1!", 25),
+	strings.Repeat("4@8(3619!|70$5+%2", 6),
+}
+
+func BenchmarkPasswordStrength(b *testing.B) {
+	for _, pw := range adversarialPasswords {
+		PasswordStrength(pw)
+		b.Run(pw[:4], func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				PasswordStrength(pw)
+			}
+		})
+	}
+}
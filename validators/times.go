@@ -0,0 +1,156 @@
+package validators
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/PlanitarInc/validate"
+)
+
+var (
+	timeTypeErr   = validate.NewError("time.type", "Should be a time", nil)
+	timeFormatErr = validate.NewError("time.invalid", "Should be an RFC 3339 time", nil)
+	timeZeroErr   = validate.NewError("time.zero", "Should be set", nil)
+
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// timeRule adapts r into a validator for time.Time, *time.Time (nil being
+// valid) and RFC 3339 strings, byte arrays and string arrays.
+func timeRule(r validate.Rule[time.Time]) validate.ValidatorFn {
+	tm := r.Fn(timeTypeErr)
+	str := validate.Strings(func(s string) interface{} {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return timeFormatErr
+		}
+		return r(t)
+	}, timeTypeErr)
+
+	return validate.Typed(func(src interface{}) interface{} {
+		switch src.(type) {
+		case time.Time, *time.Time:
+			return tm(src)
+		}
+		return str(src)
+	}, func(t reflect.Type) bool {
+		return t == timeType || t == reflect.PtrTo(timeType) || validate.Accepts(str, t)
+	})
+}
+
+// parseTimeParam parses the bound of after and before: an RFC 3339 time or
+// a date, as for defaults.
+func parseTimeParam(param string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", param)
+	}
+	if err != nil {
+		return t, errors.New("expected an RFC 3339 time or a date (2006-01-02)")
+	}
+	return t, nil
+}
+
+var notzeroValidator = func() validate.ValidatorFn {
+	fn := timeRule(func(t time.Time) interface{} {
+		if t.IsZero() {
+			return timeZeroErr
+		}
+		return nil
+	})
+	return func(src interface{}) interface{} {
+		if t, ok := src.(*time.Time); ok && t == nil {
+			return timeZeroErr
+		}
+		return fn(src)
+	}
+}()
+
+func afterValidator(param string) (validate.ValidatorFn, error) {
+	bound, err := parseTimeParam(param)
+	if err != nil {
+		return nil, err
+	}
+	return timeRule(func(t time.Time) interface{} {
+		if !t.After(bound) {
+			return validate.NewError("time.after", "Should be after "+param, validate.Params{"after": bound})
+		}
+		return nil
+	}), nil
+}
+
+func beforeValidator(param string) (validate.ValidatorFn, error) {
+	bound, err := parseTimeParam(param)
+	if err != nil {
+		return nil, err
+	}
+	return timeRule(func(t time.Time) interface{} {
+		if !t.Before(bound) {
+			return validate.NewError("time.before", "Should be before "+param, validate.Params{"before": bound})
+		}
+		return nil
+	}), nil
+}
+
+func pastValidator(now func() time.Time) validate.ValidatorFn {
+	return timeRule(func(t time.Time) interface{} {
+		if !t.Before(now()) {
+			return validate.NewError("time.past", "Should be in the past", nil)
+		}
+		return nil
+	})
+}
+
+func futureValidator(now func() time.Time) validate.ValidatorFn {
+	return timeRule(func(t time.Time) interface{} {
+		if !t.After(now()) {
+			return validate.NewError("time.future", "Should be in the future", nil)
+		}
+		return nil
+	})
+}
+
+func withinValidator(now func() time.Time) func(param string) (validate.ValidatorFn, error) {
+	return func(param string) (validate.ValidatorFn, error) {
+		d, err := time.ParseDuration(param)
+		if err != nil || d < 0 {
+			return nil, errors.New("expected a positive duration, e.g. 720h")
+		}
+		withinErr := validate.NewError("time.within", "Should be within "+d.String()+" of now",
+			validate.Params{"within": d})
+
+		return timeRule(func(t time.Time) interface{} {
+			if diff := t.Sub(now()); diff > d || diff < -d {
+				return withinErr
+			}
+			return nil
+		}), nil
+	}
+}
+
+// WithClock returns a copy of v in which the validators that depend on the
+// current time, past, future, within, uuid7 and passwordstrength, read it
+// from now instead of time.Now, e.g. for deterministic tests:
+//
+//	fixed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	vd := validators.WithClock(validators.V, func() time.Time { return fixed })
+//
+// The time validators of V work on time.Time, *time.Time and RFC 3339
+// strings; after and before take an RFC 3339 time or a date as parameter
+// and within a duration:
+//
+//	Expires time.Time `validate:"notzero,future,within=720h"`
+//	Born    string    `validate:"after=1900-01-01,past"`
+func WithClock(v validate.V, now func() time.Time) validate.V {
+	res := make(validate.V, len(v)+5)
+	for name, fn := range v {
+		res[name] = fn
+	}
+	res["past"] = pastValidator(now)
+	res["future"] = futureValidator(now)
+	res["within"] = validate.Param(withinValidator(now))
+	res["uuid7"] = uuid7Validator(now)
+	res["passwordstrength"] = validate.Param(strengthValidator(now))
+	return res
+}
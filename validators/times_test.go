@@ -0,0 +1,72 @@
+package validators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PlanitarInc/validate"
+	. "github.com/onsi/gomega"
+)
+
+var testNow = time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func TestTimeValidators(t *testing.T) {
+	RegisterTestingT(t)
+
+	vd := WithClock(V, func() time.Time { return testNow })
+	pastErr := validate.NewError("time.past", "Should be in the past", nil)
+
+	past := vd["past"]
+	Ω(past(testNow.Add(-time.Second))).Should(BeNil())
+	Ω(past(testNow)).Should(Equal(pastErr))
+	Ω(past("2024-06-15T11:00:00Z")).Should(BeNil())
+	Ω(past("2024-06-15T14:00:00+03:00")).Should(BeNil())
+	Ω(past([]byte("2030-01-01T00:00:00Z"))).Should(Equal(pastErr))
+	Ω(past([]string{"2020-01-01T00:00:00Z", "2030-01-01T00:00:00Z"})).Should(Equal([]interface{}{nil, pastErr}))
+	Ω(past((*time.Time)(nil))).Should(BeNil())
+	Ω(past("2024-06-15")).Should(Equal(timeFormatErr))
+	Ω(past(42)).Should(Equal(timeTypeErr))
+
+	future := vd["future"]
+	tomorrow := testNow.AddDate(0, 0, 1)
+	Ω(future(&tomorrow)).Should(BeNil())
+	Ω(future(testNow)).Should(Equal(validate.NewError("time.future", "Should be in the future", nil)))
+
+	Ω(V["past"](time.Now().Add(-time.Minute))).Should(BeNil())
+	Ω(V["future"](time.Now().Add(time.Minute))).Should(BeNil())
+
+	Ω(notzeroValidator(testNow)).Should(BeNil())
+	Ω(notzeroValidator(time.Time{})).Should(Equal(timeZeroErr))
+	Ω(notzeroValidator((*time.Time)(nil))).Should(Equal(timeZeroErr))
+	Ω(notzeroValidator("0001-01-01T00:00:00Z")).Should(Equal(timeZeroErr))
+}
+
+func TestTimeTags(t *testing.T) {
+	RegisterTestingT(t)
+
+	vd := WithClock(V, func() time.Time { return testNow })
+	type Subscription struct {
+		Start   time.Time  `validate:"notzero,after=2020-01-01,past"`
+		Expires *time.Time `validate:"future,within=720h"`
+		Trial   string     `validate:"before=2024-07-01T00:00:00Z"`
+	}
+	exp := testNow.Add(240 * time.Hour)
+	Ω(vd.Validate(Subscription{testNow.AddDate(-1, 0, 0), &exp, "2024-06-01T00:00:00Z"})).Should(BeNil())
+	Ω(vd.Validate(Subscription{Start: testNow.AddDate(-1, 0, 0), Trial: "2020-01-01T00:00:00Z"})).Should(BeNil())
+
+	exp = testNow.Add(1000 * time.Hour)
+	errs := vd.Validate(Subscription{time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), &exp, "2024-07-01T00:00:00Z"})
+	Ω(errs).Should(Equal(map[string]interface{}{
+		"Start": validate.NewError("time.after", "Should be after 2020-01-01",
+			validate.Params{"after": time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}),
+		"Expires": validate.NewError("time.within", "Should be within 720h0m0s of now",
+			validate.Params{"within": 720 * time.Hour}),
+		"Trial": validate.NewError("time.before", "Should be before 2024-07-01T00:00:00Z",
+			validate.Params{"before": time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)}),
+	}))
+	Ω(vd.Validate(Subscription{})).Should(Equal(map[string]interface{}{"Start": timeZeroErr, "Trial": timeFormatErr}))
+
+	Ω(vd.Var("within=-1h", testNow).(validate.Error).Code).Should(Equal("validator.params"))
+	Ω(vd.Var("after=yesterday", testNow).(validate.Error).Code).Should(Equal("validator.params"))
+	Ω(vd.Var("within=1h", testNow.Add(-30*time.Minute))).Should(BeNil())
+}
@@ -4,19 +4,20 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/PlanitarInc/validate"
+	"golang.org/x/text/unicode/norm"
 )
 
-const (
-	/* Note: synchronize it with PlanitarInc/walk-inside-app:app/lib/utils.js, or
-	 * https://github.com/PlanitarInc/walk-inside-app/blob/master/app/lib/utils.js
-	 */
-	/* XXX This should be sufficient for 99% of the cases */
-	idPattern         = "([0-9a-zA-Z][-0-9a-zA-Z.+_]*)?[0-9a-zA-Z]"
-	domainnamePattern = idPattern + "\\.[a-zA-Z]{2,10}"
-	emailPattern      = "^" + idPattern + "@" + domainnamePattern + "$"
+var (
+	emailErr    = validate.NewError("email.invalid", "invalid email", nil)
+	passwordErr = validate.NewError("password.invalid", "invalid password", nil)
+	notnullErr  = validate.NewError("value.null", "Expected non null pointer", nil)
+	strTypeErr  = validate.NewError("string.type", "Should be a string", nil)
+	textTypeErr = validate.NewError("string.type", "Should be a string or byte array", nil)
 )
 
 var (
@@ -35,59 +36,142 @@ var (
 		"strlimit-0-512":  StrLimit(0, 512),
 		"strlimit-0-1024": StrLimit(0, 1024),
 		"strlimit-0-2048": StrLimit(0, 2048),
-		"email":           REMatch(emailPattern, "invalid email"),
+		"email":           Email(emailOptions[""]),
 		"password":        PasswordValidator,
-	}
-)
 
-func nonnegativeValidator(src interface{}) interface{} {
-	negative := false
+		"passwordstrength": validate.Param(strengthValidator(time.Now)),
 
-	switch src.(type) {
-	default:
-		return "Should be an integer"
-
-	case int8:
-		n := src.(int8)
-		negative = n < 0
-	case int16:
-		n := src.(int16)
-		negative = n < 0
-	case int32:
-		n := src.(int32)
-		negative = n < 0
-	case int64:
-		n := src.(int64)
-		negative = n < 0
-	case int:
-		n := src.(int)
-		negative = n < 0
-	}
+		"email=strict":  Email(emailOptions["strict"]),
+		"email(strict)": Email(emailOptions["strict"]),
+		"email=rfc":     Email(emailOptions["rfc"]),
+		"email(rfc)":    Email(emailOptions["rfc"]),
 
-	if negative {
-		return "Should be nonnegative"
-	}
+		"positive":   positiveValidator,
+		"min":        validate.Param(minValidator),
+		"max":        validate.Param(maxValidator),
+		"range":      validate.Param(rangeValidator),
+		"multipleof": validate.Param(multipleOfValidator),
 
-	return nil
-}
+		"alpha":      validate.Param(charsValidator("alpha")),
+		"alnum":      validate.Param(charsValidator("alnum")),
+		"numeric":    validate.Param(charsValidator("numeric")),
+		"ascii":      validate.Param(charsValidator("ascii")),
+		"printable":  validate.Param(charsValidator("printable")),
+		"nocontrol":  validate.Param(charsValidator("nocontrol")),
+		"singleline": validate.Param(charsValidator("singleline")),
+		"lowercase":  validate.Param(charsValidator("lowercase")),
+		"uppercase":  validate.Param(charsValidator("uppercase")),
 
-func nonemptyValidator(src interface{}) interface{} {
-	str, ok := src.(string)
-	if !ok {
-		return "Should be a string"
-	}
+		"bytelen":     validate.Param(lengthValidator(Bytes)),
+		"runelen":     validate.Param(lengthValidator(Runes)),
+		"utf16len":    validate.Param(lengthValidator(UTF16)),
+		"graphemelen": validate.Param(lengthValidator(Graphemes)),
+
+		"url": validate.Param(urlValidator),
+		"httpurl": URL(URLOptions{
+			Schemes:     []string{"http", "https"},
+			RequireHost: true,
+			NoUserinfo:  true,
+		}),
+		"publicurl": URL(URLOptions{
+			Schemes:     []string{"http", "https"},
+			RequireHost: true,
+			NoUserinfo:  true,
+			NoPrivate:   true,
+		}),
+
+		"ip":       ipValidator,
+		"ipv4":     ipv4Validator,
+		"ipv6":     ipv6Validator,
+		"cidr":     cidrValidator,
+		"mac":      macValidator,
+		"hostname": hostnameValidator,
+		"fqdn":     fqdnValidator,
+		"hostport": hostportValidator,
+		"port":     portValidator,
+
+		"phone": validate.Param(phoneValidator),
+		"e164":  validate.Param(e164),
 
-	if len(str) == 0 {
-		return "Should be nonempty"
+		"uuid":     uuidValidator,
+		"uuid4":    uuid4Validator,
+		"uuid7":    uuid7Validator(time.Now),
+		"ulid":     ulidValidator,
+		"ksuid":    ksuidValidator,
+		"objectid": objectidValidator,
+
+		"iso3166_alpha2": validate.Param(isoValidator("iso3166_alpha2")),
+		"iso3166_alpha3": validate.Param(isoValidator("iso3166_alpha3")),
+		"iso3166_2":      validate.Param(isoValidator("iso3166_2")),
+		"iso4217":        validate.Param(isoValidator("iso4217")),
+		"bcp47":          validate.Param(isoValidator("bcp47")),
+
+		"creditcard": validate.Param(creditCardValidator),
+		"iban":       ibanValidator,
+		"bic":        bicValidator,
+
+		"isbn":   isbnValidator,
+		"isbn10": isbn10Validator,
+		"isbn13": isbn13Validator,
+		"ean8":   ean8Validator,
+		"ean13":  ean13Validator,
+		"upca":   upcaValidator,
+		"gtin14": gtin14Validator,
+
+		"past":    pastValidator(time.Now),
+		"future":  futureValidator(time.Now),
+		"within":  validate.Param(withinValidator(time.Now)),
+		"after":   validate.Param(afterValidator),
+		"before":  validate.Param(beforeValidator),
+		"notzero": notzeroValidator,
+
+		"datetime":  validate.Param(datetimeValidator),
+		"duration":  validate.Param(durationValidator),
+		"int":       validate.Param(intValidator),
+		"float":     validate.Param(floatValidator),
+		"bool":      boolValidator,
+		"json":      jsonValidator,
+		"base64":    base64Validator,
+		"base64url": base64urlValidator,
+		"hex":       hexValidator,
+		"parses":    validate.Param(parsesValidator),
+
+		"semver":    validate.Param(semverValidator),
+		"semvergt":  validate.Param(semverCmpValidator(false)),
+		"semvergte": validate.Param(semverCmpValidator(true)),
+
+		"trim":        StrModifier(strings.TrimSpace),
+		"lower":       StrModifier(strings.ToLower),
+		"upper":       StrModifier(strings.ToUpper),
+		"collapse_ws": StrModifier(collapseSpace),
+		"nfc":         StrModifier(norm.NFC.String),
 	}
+)
 
-	return nil
-}
+var nonemptyValidator = func() validate.ValidatorFn {
+	emptyErr := validate.NewError("string.empty", "Should be nonempty", nil)
+	fn := validate.Rule[string](func(str string) interface{} {
+		if len(str) == 0 {
+			return emptyErr
+		}
+
+		return nil
+	}).Fn(strTypeErr)
+	/* A nil pointer holds no string, so it is empty rather than valid */
+	return func(src interface{}) interface{} {
+		if v := reflect.ValueOf(src); v.Kind() == reflect.Ptr && v.IsNil() {
+			return emptyErr
+		}
+		return fn(src)
+	}
+}()
 
 func StrLimit(min, max uint) validate.ValidatorFn {
-	typErr := "Should be a string or byte array"
-	minErr := fmt.Sprintf("Minimum length is %d", min)
-	maxErr := fmt.Sprintf("Maximum length is %d", max)
+	typErr := validate.NewError("string.type", "Should be a string or byte array", nil)
+	minErr := validate.NewError("string.too_short",
+		fmt.Sprintf("Minimum length is %d", min), validate.Params{"min": min})
+	maxErr := validate.NewError("string.too_long",
+		fmt.Sprintf("Maximum length is %d", max), validate.Params{"max": max})
 	validate := func(length uint) interface{} {
 		if length < min {
 			return minErr
@@ -131,7 +215,7 @@ func notnullValidator(src interface{}) interface{} {
 	switch val.Kind() {
 	default:
 		if src == nil {
-			return "Expected non null pointer"
+			return notnullErr
 		}
 		return nil
 
@@ -143,7 +227,7 @@ func notnullValidator(src interface{}) interface{} {
 		fallthrough
 	case reflect.Slice:
 		if val.IsNil() {
-			return "Expected non null pointer"
+			return notnullErr
 		}
 
 		return nil
@@ -154,61 +238,41 @@ func REMatch(pattern string, mismatchError ...interface{}) validate.ValidatorFn
 	re := regexp.MustCompile(pattern)
 	var mismatchErr interface{}
 	if len(mismatchError) == 0 {
-		mismatchErr = "Value should match the pattern: " + pattern
+		mismatchErr = validate.NewError("string.pattern",
+			"Value should match the pattern: "+pattern, validate.Params{"pattern": pattern})
 	} else {
 		mismatchErr = mismatchError[0]
 	}
 
-	return func(src interface{}) interface{} {
-		var match bool
-		switch src.(type) {
-		default:
-			return "Unsupported type"
-
-		case []byte:
-			match = re.Match(src.([]byte))
-		case string:
-			match = re.MatchString(src.(string))
-		case []string:
-			arr := src.([]string)
-			errArr := make([]interface{}, len(arr))
-			match = true
-			for i := range arr {
-				if re.MatchString(arr[i]) {
-					continue
-				}
-				errArr[i] = mismatchErr
-				match = false
-			}
-			if !match {
-				return errArr
-			}
-			return nil
-		}
-		if !match {
+	typeErr := validate.NewError("string.type", "Unsupported type", nil)
+	return validate.Strings(func(str string) interface{} {
+		if !re.MatchString(str) {
 			return mismatchErr
 		}
 		return nil
-	}
+	}, typeErr)
 }
 
+// PasswordValidator checks passwords against a fixed policy: 8 to 128
+// characters with a lowercase letter, an uppercase letter and a digit. It
+// reports any failure as "invalid password"; PasswordPolicy allows other
+// policies and reports the unmet requirements.
 func PasswordValidator(src interface{}) interface{} {
-	str, ok := src.(string)
-	if !ok {
-		return "invalid password"
-	}
+	return passwordRule(src)
+}
 
+var passwordRule = validate.Rule[string](func(str string) interface{} {
 	if len(str) < 8 || len(str) > 128 {
-		return "invalid password"
+		return passwordErr
 	}
 	if m, e := regexp.MatchString("[a-z]", str); !m || e != nil {
-		return "invalid password"
+		return passwordErr
 	}
 	if m, e := regexp.MatchString("[A-Z]", str); !m || e != nil {
-		return "invalid password"
+		return passwordErr
 	}
 	if m, e := regexp.MatchString("[0-9]", str); !m || e != nil {
-		return "invalid password"
+		return passwordErr
 	}
 	return nil
-}
+}).Fn(passwordErr)
@@ -2,8 +2,11 @@ package validators
 
 import (
 	"fmt"
+	"math/big"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/PlanitarInc/validate"
 	. "github.com/onsi/gomega"
@@ -12,8 +15,8 @@ import (
 func TestNonnegativeValidator(t *testing.T) {
 	RegisterTestingT(t)
 
-	nonnegativeErr := "Should be nonnegative"
-	nonintegerErr := "Should be an integer"
+	nonnegativeErr := validate.NewError("number.negative", "Should be nonnegative", nil)
+	nonnumberErr := validate.NewError("number.type", "Should be a number", nil)
 
 	Ω(nonnegativeValidator(0)).ShouldNot(HaveOccurred())
 	Ω(nonnegativeValidator(123)).ShouldNot(HaveOccurred())
@@ -35,22 +38,26 @@ func TestNonnegativeValidator(t *testing.T) {
 	Ω(nonnegativeValidator(int64(131))).ShouldNot(HaveOccurred())
 	Ω(nonnegativeValidator(int64(-97))).Should(Equal(nonnegativeErr))
 
-	Ω(nonnegativeValidator(1.1)).Should(Equal(nonintegerErr))
-	Ω(nonnegativeValidator("1")).Should(Equal(nonintegerErr))
-	Ω(nonnegativeValidator(nil)).Should(Equal(nonintegerErr))
+	Ω(nonnegativeValidator(uint(0))).ShouldNot(HaveOccurred())
+	Ω(nonnegativeValidator(1.1)).ShouldNot(HaveOccurred())
+	Ω(nonnegativeValidator(-0.1)).Should(Equal(nonnegativeErr))
+
+	Ω(nonnegativeValidator("1")).Should(Equal(nonnumberErr))
+	Ω(nonnegativeValidator(nil)).Should(Equal(nonnumberErr))
 }
 
 func TestNonemptyValidator(t *testing.T) {
 	RegisterTestingT(t)
 
-	nonstringErr := "Should be a string"
-	nonemptyErr := "Should be nonempty"
+	nonstringErr := validate.NewError("string.type", "Should be a string", nil)
+	nonemptyErr := validate.NewError("string.empty", "Should be nonempty", nil)
 
 	Ω(nonemptyValidator("")).Should(Equal(nonemptyErr))
 	Ω(nonemptyValidator(".")).ShouldNot(HaveOccurred())
 	Ω(nonemptyValidator("asb")).ShouldNot(HaveOccurred())
 
 	Ω(nonemptyValidator(nil)).Should(Equal(nonstringErr))
+	Ω(nonemptyValidator((*string)(nil))).Should(Equal(nonemptyErr))
 	Ω(nonemptyValidator(1)).Should(Equal(nonstringErr))
 	Ω(nonemptyValidator(1.1)).Should(Equal(nonstringErr))
 }
@@ -58,9 +65,15 @@ func TestNonemptyValidator(t *testing.T) {
 func TestStrLimitValidator(t *testing.T) {
 	RegisterTestingT(t)
 
-	nonstringErr := "Should be a string or byte array"
-	maxErr := func(n int) string { return fmt.Sprintf("Maximum length is %d", n) }
-	minErr := func(n int) string { return fmt.Sprintf("Minimum length is %d", n) }
+	nonstringErr := validate.NewError("string.type", "Should be a string or byte array", nil)
+	maxErr := func(n uint) validate.Error {
+		return validate.NewError("string.too_long",
+			fmt.Sprintf("Maximum length is %d", n), validate.Params{"max": n})
+	}
+	minErr := func(n uint) validate.Error {
+		return validate.NewError("string.too_short",
+			fmt.Sprintf("Minimum length is %d", n), validate.Params{"min": n})
+	}
 
 	Ω(StrLimit(0, 0)("")).ShouldNot(HaveOccurred())
 	Ω(StrLimit(2, 2)("aa")).ShouldNot(HaveOccurred())
@@ -80,12 +93,12 @@ func TestStrLimitValidator(t *testing.T) {
 	arr := []string{}
 	Ω(StrLimit(1, 1)(arr)).Should(BeNil())
 	arr = []string{""}
-	errs := map[int]string{0: minErr(1)}
+	errs := map[int]validate.Error{0: minErr(1)}
 	Ω(StrLimit(1, 1)(arr)).ShouldNot(Equal(errs))
 	arr = []string{"a"}
 	Ω(StrLimit(1, 1)(arr)).Should(BeNil())
 	arr = []string{"", "asd", "bsd", "qs", ""}
-	errs = map[int]string{0: minErr(1), 1: maxErr(2), 2: maxErr(2), 4: minErr(1)}
+	errs = map[int]validate.Error{0: minErr(1), 1: maxErr(2), 2: maxErr(2), 4: minErr(1)}
 	e := StrLimit(1, 2)(arr)
 	Ω(e).Should(HaveKeyWithValue(0, minErr(1)))
 	Ω(e).Should(HaveKeyWithValue(1, maxErr(2)))
@@ -99,22 +112,24 @@ func TestStrLimitValidator(t *testing.T) {
 func TestNotNull(t *testing.T) {
 	RegisterTestingT(t)
 
-	Ω(notnullValidator(nil)).Should(Equal("Expected non null pointer"))
+	notnullErr := validate.NewError("value.null", "Expected non null pointer", nil)
+
+	Ω(notnullValidator(nil)).Should(Equal(notnullErr))
 	{
 		var src interface{}
-		Ω(notnullValidator(src)).Should(Equal("Expected non null pointer"))
+		Ω(notnullValidator(src)).Should(Equal(notnullErr))
 	}
 	{
 		var src map[string]interface{}
-		Ω(notnullValidator(src)).Should(Equal("Expected non null pointer"))
+		Ω(notnullValidator(src)).Should(Equal(notnullErr))
 	}
 	{
 		var src []int
-		Ω(notnullValidator(src)).Should(Equal("Expected non null pointer"))
+		Ω(notnullValidator(src)).Should(Equal(notnullErr))
 	}
 	{
 		var src *struct{ X int }
-		Ω(notnullValidator(src)).Should(Equal("Expected non null pointer"))
+		Ω(notnullValidator(src)).Should(Equal(notnullErr))
 	}
 
 	{
@@ -140,8 +155,9 @@ func TestNotNull(t *testing.T) {
 func TestRegexpValidator(t *testing.T) {
 	RegisterTestingT(t)
 
-	errMsg := func(p string) string {
-		return "Value should match the pattern: " + p
+	errMsg := func(p string) validate.Error {
+		return validate.NewError("string.pattern",
+			"Value should match the pattern: "+p, validate.Params{"pattern": p})
 	}
 
 	Ω(REMatch("")("")).Should(BeNil())
@@ -167,47 +183,93 @@ func TestRegexpValidator(t *testing.T) {
 	Ω(v("ababa")).Should(Equal("fail"))
 	Ω(v([]byte("aa"))).Should(Equal("fail"))
 
+	Ω(REMatch("a")(1)).Should(Equal(validate.NewError("string.type", "Unsupported type", nil)))
 	Ω(notnullValidator(1)).ShouldNot(Equal("Unsupported type"))
 }
 
 func TestEmailValidator(t *testing.T) {
 	RegisterTestingT(t)
 
+	emailErr := validate.NewError("email.invalid", "invalid email", nil)
+
 	v, ok := V["email"]
 	Ω(ok).Should(BeTrue())
 
 	Ω(v("d@p.aa")).Should(BeNil())
 	Ω(v("dmitri@planitar.com")).Should(BeNil())
 	Ω(v("D.m.I.t.R.i@p.L.a.N.i.T.a.R.cOm")).Should(BeNil())
-
-	Ω(v("-bad.@addr.com")).Should(Equal("invalid email"))
-	Ω(v("bad-@addr.com")).Should(Equal("invalid email"))
-	Ω(v(".bad.@addr.com")).Should(Equal("invalid email"))
-	Ω(v("bad.@addr.com")).Should(Equal("invalid email"))
-	Ω(v("@bad.com")).Should(Equal("invalid email"))
-	Ω(v("a@bad.")).Should(Equal("invalid email"))
-	Ω(v("a@.bad.com")).Should(Equal("invalid email"))
-	Ω(v("a@a")).Should(Equal("invalid email"))
-	Ω(v("@")).Should(Equal("invalid email"))
-	Ω(v("")).Should(Equal("invalid email"))
-	Ω(v("sdasd.asdas.com")).Should(Equal("invalid email"))
+	Ω(v("bad-@addr.com")).Should(BeNil())
+	Ω(v("a@b.c")).Should(BeNil())
+	Ω(v("studio@example.photography")).Should(BeNil())
+	Ω(v("\"john smith\"@example.com")).Should(BeNil())
+	Ω(v("user@b\u00fccher.example")).Should(BeNil())
+
+	Ω(v("-bad.@addr.com")).Should(Equal(emailErr))
+	Ω(v(".bad.@addr.com")).Should(Equal(emailErr))
+	Ω(v("bad.@addr.com")).Should(Equal(emailErr))
+	Ω(v("@bad.com")).Should(Equal(emailErr))
+	Ω(v("a@bad.")).Should(Equal(emailErr))
+	Ω(v("a@.bad.com")).Should(Equal(emailErr))
+	Ω(v("a@a")).Should(Equal(emailErr))
+	Ω(v("@")).Should(Equal(emailErr))
+	Ω(v("")).Should(Equal(emailErr))
+	Ω(v("sdasd.asdas.com")).Should(Equal(emailErr))
 }
 
 func TestPasswordValidator(t *testing.T) {
 	RegisterTestingT(t)
 
-	Ω(PasswordValidator("")).Should(Equal("invalid password"))
-	Ω(PasswordValidator("bcDEF67")).Should(Equal("invalid password"))
-	Ω(PasswordValidator("aaaaaaaaa")).Should(Equal("invalid password"))
-	Ω(PasswordValidator("AAAAAAAAA")).Should(Equal("invalid password"))
-	Ω(PasswordValidator("aAaAaAaAa")).Should(Equal("invalid password"))
-	Ω(PasswordValidator("1010101010")).Should(Equal("invalid password"))
-	Ω(PasswordValidator("aaaa101010")).Should(Equal("invalid password"))
+	passwordErr := validate.NewError("password.invalid", "invalid password", nil)
+
+	Ω(PasswordValidator("")).Should(Equal(passwordErr))
+	Ω(PasswordValidator("bcDEF67")).Should(Equal(passwordErr))
+	Ω(PasswordValidator("aaaaaaaaa")).Should(Equal(passwordErr))
+	Ω(PasswordValidator("AAAAAAAAA")).Should(Equal(passwordErr))
+	Ω(PasswordValidator("aAaAaAaAa")).Should(Equal(passwordErr))
+	Ω(PasswordValidator("1010101010")).Should(Equal(passwordErr))
+	Ω(PasswordValidator("aaaa101010")).Should(Equal(passwordErr))
 
 	Ω(PasswordValidator("bcDEF67_")).Should(BeNil())
 	Ω(PasswordValidator("Aaaa101010")).Should(BeNil())
 }
 
+func TestNilPointers(t *testing.T) {
+	RegisterTestingT(t)
+
+	type X struct {
+		Email    *string    `validate:"email"`
+		Password *string    `validate:"password"`
+		Code     *string    `validate:"code"`
+		URL      *string    `validate:"url"`
+		Phone    *string    `validate:"phone"`
+		Count    *int       `validate:"min=1"`
+		At       *time.Time `validate:"past"`
+
+		Name *string    `validate:"nonempty"`
+		Ptr  *int       `validate:"notnull"`
+		Set  *time.Time `validate:"notzero"`
+	}
+	vd := validate.V{"code": REMatch("^[a-z]+$")}
+	for name, fn := range V {
+		vd[name] = fn
+	}
+
+	/* Nil pointers have no value to check; only presence checks fail them */
+	Ω(vd.Validate(X{})).Should(Equal(map[string]interface{}{
+		"Name": validate.NewError("string.empty", "Should be nonempty", nil),
+		"Ptr":  notnullErr,
+		"Set":  timeZeroErr,
+	}))
+
+	email, password, code, url, phone := "a@example.com", "Aaaa101010", "abc", "https://example.com", "+14155550123"
+	name, n, at := "x", 1, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	Ω(vd.Validate(X{&email, &password, &code, &url, &phone, &n, &at, &name, &n, &at})).Should(BeNil())
+
+	bad := "-"
+	Ω(vd.Validate(X{Email: &bad, Password: &bad, Code: &bad, Name: &name, Ptr: &n, Set: &at})).Should(
+		SatisfyAll(HaveKey("Email"), HaveKey("Password"), HaveKey("Code"), HaveLen(3)))
+}
+
 func TestValidatorArray(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -222,27 +284,83 @@ func TestValidatorArray(t *testing.T) {
 
 	v, ok = V["notnull"]
 	Ω(ok).Should(BeTrue())
-	Ω(v(nil)).Should(Equal("Expected non null pointer"))
+	Ω(v(nil)).Should(MatchError("Expected non null pointer"))
 	Ω(v(&struct{}{})).ShouldNot(HaveOccurred())
 
 	v, ok = V["strlimit-2-2"]
 	Ω(ok).Should(BeTrue())
-	Ω(v("1")).Should(Equal("Minimum length is 2"))
-	Ω(v("123")).Should(Equal("Maximum length is 2"))
+	Ω(v("1")).Should(MatchError("Minimum length is 2"))
+	Ω(v("1").(validate.Error).Code).Should(Equal("string.too_short"))
+	Ω(v("123")).Should(MatchError("Maximum length is 2"))
 
 	v, ok = V["strlimit-1-20"]
 	Ω(ok).Should(BeTrue())
-	Ω(v("")).Should(Equal("Minimum length is 1"))
-	Ω(v(strings.Repeat("1", 21))).Should(Equal("Maximum length is 20"))
+	Ω(v("")).Should(MatchError("Minimum length is 1"))
+	Ω(v(strings.Repeat("1", 21))).Should(MatchError("Maximum length is 20"))
 
 	v, ok = V["strlimit-1-128"]
 	Ω(ok).Should(BeTrue())
-	Ω(v("")).Should(Equal("Minimum length is 1"))
-	Ω(v(strings.Repeat("1", 129))).Should(Equal("Maximum length is 128"))
+	Ω(v("")).Should(MatchError("Minimum length is 1"))
+	Ω(v(strings.Repeat("1", 129))).Should(MatchError("Maximum length is 128"))
 
 	/* Presence of email validator was tested in TestEmailValidator() */
 
 	v, ok = V["password"]
 	Ω(ok).Should(BeTrue())
-	Ω(v("")).Should(Equal("invalid password"))
+	Ω(v("")).Should(MatchError("invalid password"))
+}
+
+func TestStrModifier(t *testing.T) {
+	RegisterTestingT(t)
+
+	type Name string
+
+	Ω(V["trim"]("  a b  ")).Should(Equal(validate.Modified("a b")))
+	Ω(V["lower"]("AbC")).Should(Equal(validate.Modified("abc")))
+	Ω(V["upper"]([]byte("AbC"))).Should(Equal(validate.Modified([]byte("ABC"))))
+	Ω(V["collapse_ws"](" a \t\n b  c ")).Should(Equal(validate.Modified("a b c")))
+	Ω(V["nfc"]("e\u0301")).Should(Equal(validate.Modified("\u00e9")))
+	Ω(V["trim"]([]string{" a", "b "})).Should(Equal(validate.Modified([]string{"a", "b"})))
+	Ω(V["trim"](Name(" a "))).Should(Equal(validate.Modified(Name("a"))))
+	Ω(V["trim"](1)).Should(Equal(validate.NewError("string.type", "Should be a string", nil)))
+
+	type X struct {
+		Email string `validate:"trim,lower,email"`
+		Tags  []string
+	}
+	x := X{Email: "  Dmitri@Planitar.COM "}
+	Ω(V.Validate(&x)).Should(BeNil())
+	Ω(x.Email).Should(Equal("dmitri@planitar.com"))
+}
+
+func TestCheckType(t *testing.T) {
+	RegisterTestingT(t)
+
+	type Account struct {
+		Email    string     `validate:"trim,lower,email"`
+		Name     *string    `validate:"nonempty,strlimit-1-128"`
+		Tags     []string   `validate:"runelen=1..10"`
+		Phone    []byte     `validate:"e164=US,phone=US|CA"`
+		Age      *uint8     `validate:"min=13,max=130"`
+		Balance  big.Rat    `validate:"nonnegative"`
+		Born     time.Time  `validate:"past"`
+		Closed   *time.Time `validate:"notzero"`
+		Birthday string     `validate:"past"`
+	}
+	Ω(V.CheckType(reflect.TypeOf(Account{}))).Should(Succeed())
+
+	for tag, typ := range map[string]interface{}{
+		"email":     1,
+		"min=13":    "13",
+		"trim":      []int{},
+		"past":      int64(0),
+		"nonempty":  []byte{},
+		"phone=XX":  "",
+		"iso4217=x": "",
+	} {
+		st := reflect.StructOf([]reflect.StructField{{
+			Name: "A", Type: reflect.TypeOf(typ), Tag: reflect.StructTag(`validate:"` + tag + `"`),
+		}})
+		Ω(V.CheckType(st)).Should(HaveOccurred(), tag)
+	}
 }